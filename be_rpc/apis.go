@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -42,6 +43,10 @@ var messageParsers map[string]berpctypes.MessageParser
 // messageInvolversExtractors defines the message involvers extractors.
 var messageInvolversExtractors map[string]berpctypes.MessageInvolversExtractor
 
+// shutdownHooks defines the functions releasing the resources of the APIs, executed on server shutdown.
+var shutdownHooks []func()
+var shutdownHooksMu sync.Mutex
+
 func init() {
 	apiCreators = map[string]APICreator{
 		DymRollAppBlockExplorerNamespace: func(ctx *server.Context,
@@ -56,11 +61,23 @@ func init() {
 			if requestInterceptorCreator != nil {
 				backend = backend.WithInterceptor(requestInterceptorCreator(backend))
 			}
+			if backend.GetConfig().IndexerEnable {
+				if err := backend.StartIndexer(ctx.Config.RootDir); err != nil {
+					// transactions by account are served using tx search instead
+					ctx.Logger.Error("failed to start indexer, continue without indexer", "error", err)
+				} else {
+					registerShutdownHook(func() {
+						if err := backend.StopIndexer(); err != nil {
+							ctx.Logger.Error("failed to stop indexer", "error", err)
+						}
+					})
+				}
+			}
 			return []rpc.API{
 				{
 					Namespace: DymRollAppBlockExplorerNamespace,
 					Version:   ApiVersion,
-					Service:   be.NewBeAPI(ctx, backend),
+					Service:   be.NewBeAPI(ctx, backend, tmWSClient),
 					Public:    true,
 				},
 			}
@@ -96,6 +113,26 @@ func GetBeRpcAPIs(ctx *server.Context,
 	return apis
 }
 
+// Shutdown releases the resources held by the APIs, like the indexer database.
+// It is registered as the shutdown hook of the server, so it might run while the requests are still being served:
+// the indexer waits for the in-flight queries before closing the database, then rejects the later queries.
+func Shutdown() {
+	shutdownHooksMu.Lock()
+	hooks := shutdownHooks
+	shutdownHooks = nil
+	shutdownHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+func registerShutdownHook(hook func()) {
+	shutdownHooksMu.Lock()
+	defer shutdownHooksMu.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
 // RegisterAPINamespace registers a new API namespace with the API creator.
 // This function fails if the namespace is already registered.
 // Legacy TODO BE: call to this function to register before startup
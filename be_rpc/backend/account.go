@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -104,6 +105,7 @@ func (m *Backend) GetAccount(accountAddressStr string) (berpctypes.GenericBacken
 			fakeBaseAccount := &berpctypes.FakeBaseAccount{}
 			extractedSuccess, err := fakeBaseAccount.TryUnmarshalFromProto(resAccount.Account, m.clientCtx.Codec)
 			if err == nil && extractedSuccess {
+				// fallback value, only counts the txs signed by the account
 				res["txsCount"] = fakeBaseAccount.Sequence + 1
 			} else if err != nil {
 				m.GetLogger().Error("failed to extract base account", "error", err)
@@ -111,6 +113,14 @@ func (m *Backend) GetAccount(accountAddressStr string) (berpctypes.GenericBacken
 		}
 	}
 
+	if m.isIndexerCaughtUp() {
+		if txsStats, err := m.getAccountTxsStats(accAddrStr); err != nil {
+			m.GetLogger().Debug("failed to get account txs stats", "address", accAddrStr, "error", err)
+		} else {
+			res["txsStats"] = txsStats
+		}
+	}
+
 	// get staking information
 
 	if !isSmartContract {
@@ -207,6 +217,24 @@ func (m *Backend) GetValidatorAccount(consOrValAddr string) (berpctypes.GenericB
 	}
 	res["staking"] = stakingInfo
 
+	resSigningInfo, err := m.queryClient.SlashingQueryClient.SigningInfo(m.ctx, &slashingtypes.QuerySigningInfoRequest{
+		ConsAddress: consAddr,
+	})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get validator signing info").Error())
+		}
+
+		// validator never bonded does not have signing info
+		m.GetLogger().Debug("validator signing info not found", "consAddress", consAddr)
+	} else {
+		signedBlocksWindow, err := m.getSignedBlocksWindow()
+		if err != nil {
+			return nil, err
+		}
+		res["signingInfo"] = signingInfoToMap(resSigningInfo.ValSigningInfo, signedBlocksWindow)
+	}
+
 	return res, nil
 }
 
@@ -223,3 +251,20 @@ func (m *Backend) isAccAddrOr0x(addr string) bool {
 
 	return false
 }
+
+// getAccountTxsStats returns the number of transactions involving, sent and received by the account,
+// with the first-seen and last-active heights. Served from the index, which must be enabled.
+func (m *Backend) getAccountTxsStats(accAddrStr string) (berpctypes.GenericBackendResponse, error) {
+	stats, err := m.indexer.GetAddressStats(accAddrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"total":            stats.TxCount,
+		"sent":             stats.Sent,
+		"received":         stats.Received,
+		"firstSeenHeight":  stats.FirstSeenHeight,
+		"lastActiveHeight": stats.LastActiveHeight,
+	}, nil
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/config"
+	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/indexer"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -28,8 +29,13 @@ type BackendI interface {
 
 	// Block
 
-	// GetBlockByNumber returns a block by its height.
-	GetBlockByNumber(height int64) (berpctypes.GenericBackendResponse, error)
+	// GetBlockByNumber returns a block by its height, or by block tag like "latest" and "earliest".
+	// The begin/end block events can be filtered by event types, all events are returned if no type provided.
+	GetBlockByNumber(blockNumber berpctypes.BlockNumber, eventTypes []string) (berpctypes.GenericBackendResponse, error)
+
+	// GetBlockByHash returns a block by its hash.
+	// The begin/end block events can be filtered by event types, all events are returned if no type provided.
+	GetBlockByHash(hash string, eventTypes []string) (berpctypes.GenericBackendResponse, error)
 
 	// Transactions
 
@@ -40,21 +46,74 @@ type BackendI interface {
 	// GetTransactionByHash returns a transaction by its hash.
 	GetTransactionByHash(hash string) (berpctypes.GenericBackendResponse, error)
 
+	// GetTransactionsByAccount returns the paginated list of transactions involving the given account.
+	// Accepts bech32 account address, validator operator address or 0x address.
+	GetTransactionsByAccount(accountAddressStr string, pageNo int) (berpctypes.GenericBackendResponse, error)
+
+	// SearchTransactions returns the paginated list of transactions matching the given event query,
+	// for example: transfer.recipient='address'. Supported order: asc, desc (default).
+	SearchTransactions(query string, pageNo int, orderBy string) (berpctypes.GenericBackendResponse, error)
+
+	// DecodeTransaction decodes the raw transaction bytes, in base64 or hex format, and parses the messages.
+	// No execution result is provided.
+	DecodeTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error)
+
+	// SimulateTransaction simulates the raw transaction bytes, in base64 or hex format,
+	// returns the gas estimation, the events and the parsed messages.
+	SimulateTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error)
+
+	// BroadcastTransaction broadcasts the raw transaction bytes, in base64 or hex format.
+	// Supported modes: sync (default), async, block (alias: commit).
+	// When the transaction is committed (block mode), the parsed transaction is returned as well.
+	BroadcastTransaction(base64OrHexTxBytes string, mode string) (berpctypes.GenericBackendResponse, error)
+
+	// GetUnconfirmedTransactions returns the pending transactions in mempool, with the mempool size.
+	GetUnconfirmedTransactions(limit int) (berpctypes.GenericBackendResponse, error)
+
+	// Indexer
+
+	// GetIndexerStatus returns the progress of the embedded address-to-transaction index, if enabled.
+	GetIndexerStatus() (berpctypes.GenericBackendResponse, error)
+
 	// Staking
 
 	// GetStakingInfo returns the staking information, includes:
 	// - Delegator's staking information
+	// - Delegator's unbonding delegations, redelegations and rewards per validator
 	// - Validator's commission & outstanding rewards
 	GetStakingInfo(delegatorAddr string) (berpctypes.GenericBackendResponse, error)
 
 	GetValidators() (berpctypes.GenericBackendResponse, error)
 
+	// GetStakingValidators returns the paginated list of validators with staking metadata, sorted by voting power.
+	// Supported status: bonded, unbonding, unbonded, empty for all.
+	GetStakingValidators(status string, pageNo int) (berpctypes.GenericBackendResponse, error)
+
+	// GetValidatorDelegations returns the paginated list of delegations to the validator, with the total delegator count.
+	GetValidatorDelegations(valAddr string, pageNo int) (berpctypes.GenericBackendResponse, error)
+
+	// GetValidatorUnbondingDelegations returns the paginated list of unbonding delegations from the validator,
+	// with the total unbonding delegator count.
+	GetValidatorUnbondingDelegations(valAddr string, pageNo int) (berpctypes.GenericBackendResponse, error)
+
+	// Slashing
+
+	// GetValidatorsUptime returns the paginated list of validators signing info,
+	// with the uptime percentage computed against the signed blocks window.
+	GetValidatorsUptime(pageNo int) (berpctypes.GenericBackendResponse, error)
+
 	// Gov
 
 	GetGovProposal(proposalId uint64) (berpctypes.GenericBackendResponse, error)
 
 	GetGovProposals(pageNo int) (berpctypes.GenericBackendResponse, error)
 
+	// Search
+
+	// Search detects the entity type of the given query, includes:
+	// block height, block hash, transaction hash, account, validator, proposal id and denom.
+	Search(query string) (berpctypes.GenericBackendResponse, error)
+
 	// Misc
 
 	GetDenomMetadata(base string) (berpctypes.GenericBackendResponse, error)
@@ -69,6 +128,7 @@ type BackendI interface {
 	GetLogger() log.Logger
 	GetConfig() config.BeJsonRpcConfig
 	GetExternalServices() berpctypes.ExternalServices
+	GetBech32Config() berpctypes.Bech32Config
 }
 
 var _ BackendI = (*Backend)(nil)
@@ -84,11 +144,13 @@ type Backend struct {
 	messageParsers             map[string]berpctypes.MessageParser
 	messageInvolversExtractors map[string]berpctypes.MessageInvolversExtractor
 	externalServices           berpctypes.ExternalServices
+	indexer                    *indexer.Indexer // optional, only available when enabled
 
 	// cache
 	bech32Cfg                   berpctypes.Bech32Config
 	tendermintValidatorsCache   *tendermintValidatorsCache
 	validatorsConsAddrToValAddr *validatorsConsAddrToValAddr
+	stakingValidatorsCache      *stakingValidatorsCache
 }
 
 // NewBackend creates a new Backend instance for RollApp Block Explorer
@@ -126,6 +188,10 @@ func NewBackend(
 			queryClient.StakingQueryClient,
 			clientCtx.Codec,
 		),
+		stakingValidatorsCache: NewStakingValidatorsCache(
+			clientCtx.Client,
+			queryClient.StakingQueryClient,
+		),
 	}
 }
 
@@ -157,3 +223,15 @@ func (m *Backend) GetConfig() config.BeJsonRpcConfig {
 func (m *Backend) GetExternalServices() berpctypes.ExternalServices {
 	return m.externalServices
 }
+
+func (m *Backend) GetBech32Config() berpctypes.Bech32Config {
+	return m.bech32Cfg
+}
+
+// getBlockFetchConcurrency returns the maximum number of blocks to be fetched concurrently, fallback to default if not set.
+func (m *Backend) getBlockFetchConcurrency() int {
+	if m.cfg.BlockFetchConcurrency < 1 {
+		return config.DefaultBlockFetchConcurrency
+	}
+	return m.cfg.BlockFetchConcurrency
+}
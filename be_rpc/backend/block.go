@@ -4,14 +4,21 @@ import (
 	"encoding/hex"
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"strings"
 )
 
-func (m *Backend) GetBlockByNumber(height int64) (berpctypes.GenericBackendResponse, error) {
+func (m *Backend) GetBlockByNumber(blockNumber berpctypes.BlockNumber, eventTypes []string) (berpctypes.GenericBackendResponse, error) {
+	height, err := m.resolveBlockNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	resBlock, err := m.queryClient.ServiceClient.GetBlockWithTxs(m.ctx, &tx.GetBlockWithTxsRequest{
 		Height: height,
 	})
@@ -36,7 +43,27 @@ func (m *Backend) GetBlockByNumber(height int64) (berpctypes.GenericBackendRespo
 		"height":       block.Header.Height,
 		"hash":         strings.ToUpper(hex.EncodeToString(resBlock.BlockId.Hash)),
 		"timeEpochUTC": block.Header.Time.UTC().Unix(),
+		"header": berpctypes.GenericBackendResponse{
+			"chainId":           block.Header.ChainID,
+			"previousBlockHash": strings.ToUpper(hex.EncodeToString(block.Header.LastBlockId.Hash)),
+			"appHash":           strings.ToUpper(hex.EncodeToString(block.Header.AppHash)),
+			"dataHash":          strings.ToUpper(hex.EncodeToString(block.Header.DataHash)),
+			"lastCommitHash":    strings.ToUpper(hex.EncodeToString(block.Header.LastCommitHash)),
+			"validatorsHash":    strings.ToUpper(hex.EncodeToString(block.Header.ValidatorsHash)),
+			"proposer":          m.getValidatorInfoFromConsAddr(block.Header.ProposerAddress),
+		},
+	}
+
+	lastCommitSigners := make([]map[string]any, 0)
+	if block.LastCommit != nil {
+		for _, signature := range block.LastCommit.Signatures {
+			if signature.BlockIdFlag != tmproto.BlockIDFlagCommit {
+				continue
+			}
+			lastCommitSigners = append(lastCommitSigners, m.getValidatorInfoFromConsAddr(signature.ValidatorAddress))
+		}
 	}
+	response["lastCommitSigners"] = lastCommitSigners
 
 	txsInfo := make([]map[string]any, 0)
 	for i, tx := range resBlock.Txs {
@@ -69,16 +96,10 @@ func (m *Backend) GetBlockByNumber(height int64) (berpctypes.GenericBackendRespo
 
 		if tx.AuthInfo != nil {
 			if tx.AuthInfo.Fee != nil {
-				txInfo["fee"] = map[string]any{
-					"gasLimit": tx.AuthInfo.Fee.GasLimit,
-					"amount":   berpcutils.CoinsToMap(tx.AuthInfo.Fee.Amount...),
-				}
+				txInfo["fee"] = feeToMap(tx.AuthInfo.Fee)
 			}
 			if tx.AuthInfo.Tip != nil {
-				txInfo["tip"] = map[string]any{
-					"tipper": tx.AuthInfo.Tip.Tipper,
-					"amount": berpcutils.CoinsToMap(tx.AuthInfo.Tip.Amount...),
-				}
+				txInfo["tip"] = tipToMap(tx.AuthInfo.Tip)
 			}
 		}
 
@@ -87,5 +108,69 @@ func (m *Backend) GetBlockByNumber(height int64) (berpctypes.GenericBackendRespo
 
 	response["txs"] = txsInfo
 
+	response["beginBlockEvents"] = berpctypes.ConvertTxEvent(resBlockResults.BeginBlockEvents).FilterByType(eventTypes...)
+	response["endBlockEvents"] = berpctypes.ConvertTxEvent(resBlockResults.EndBlockEvents).FilterByType(eventTypes...)
+
 	return response, nil
 }
+
+func (m *Backend) GetBlockByHash(hashStr string, eventTypes []string) (berpctypes.GenericBackendResponse, error) {
+	// block hash has the same format as the transaction hash
+	if !patternTxHash.MatchString(hashStr) {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	hash, err := hex.DecodeString(berpcutils.NormalizeTransactionHash(hashStr, false)[2:])
+	if err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	resBlock, err := m.clientCtx.Client.BlockByHash(m.ctx, hash)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+
+	return m.GetBlockByNumber(berpctypes.BlockNumber(resBlock.Block.Height), eventTypes)
+}
+
+// getValidatorInfoFromConsAddr returns the consensus address, with validator operator address and moniker if the validator is known.
+func (m *Backend) getValidatorInfoFromConsAddr(consAddr sdk.ConsAddress) map[string]any {
+	consAddrStr := consAddr.String()
+	validatorInfo := map[string]any{
+		"consensusAddress": consAddrStr,
+	}
+
+	valAddr, moniker, found, err := m.validatorsConsAddrToValAddr.GetValAddrAndMonikerFromConsAddr(consAddrStr)
+	if err != nil {
+		m.GetLogger().Error("failed to get validator address from consensus address", "consAddr", consAddrStr, "error", err)
+	} else if found {
+		validatorInfo["validatorAddress"] = valAddr
+		validatorInfo["moniker"] = moniker
+	}
+
+	return validatorInfo
+}
+
+// resolveBlockNumber resolves the block tags into the actual block height.
+func (m *Backend) resolveBlockNumber(blockNumber berpctypes.BlockNumber) (int64, error) {
+	if !blockNumber.IsTag() {
+		if blockNumber < 1 {
+			return 0, berpctypes.ErrBadRequest
+		}
+		return blockNumber.Int64(), nil
+	}
+
+	statusInfo, err := m.clientCtx.Client.Status(m.ctx)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	if blockNumber == berpctypes.EarliestBlockNumber {
+		return statusInfo.SyncInfo.EarliestBlockHeight, nil
+	}
+
+	return statusInfo.SyncInfo.LatestBlockHeight, nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/tendermint/tendermint/rpc/client"
 	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"sync"
 )
 
@@ -107,17 +110,29 @@ func (vc *tendermintValidatorsCache) isCacheExpired(lock bool) (expired bool, la
 	return
 }
 
+// validatorsPageSize is the page size used when paging through the validator set,
+// it is also the maximum page size allowed by Tendermint RPC.
+const validatorsPageSize = 100
+
 // reloadCacheWithoutLock performs reload cache. Lock acquire must be performed before calling this.
 func (vc *tendermintValidatorsCache) reloadCacheWithoutLock(height int64) error {
-	var page = 1
-	var perPage = 200
+	var perPage = validatorsPageSize
 
-	resValidators, err := vc.tmClient.Validators(context.Background(), &height, &page, &perPage)
-	if err != nil {
-		return err
+	var validators []*tmtypes.Validator
+	for page := 1; ; page++ {
+		resValidators, err := vc.tmClient.Validators(context.Background(), &height, &page, &perPage)
+		if err != nil {
+			return err
+		}
+
+		validators = append(validators, resValidators.Validators...)
+
+		if len(resValidators.Validators) < 1 || len(validators) >= resValidators.Total {
+			break
+		}
 	}
 
-	vc.validators = resValidators.Validators
+	vc.validators = validators
 	vc.cacheController.UpdateExpirationAnchor(height + validatorsCacheExpiration)
 
 	return nil
@@ -126,6 +141,7 @@ func (vc *tendermintValidatorsCache) reloadCacheWithoutLock(height int64) error
 type validatorsConsAddrToValAddr struct {
 	cacheController             *baseCacheController
 	validatorsConsAddrToValAddr map[string]string
+	validatorsValAddrToMoniker  map[string]string
 	tmClient                    client.Client
 	stakingQueryClient          stakingtypes.QueryClient
 	codec                       codec.Codec
@@ -138,6 +154,7 @@ func NewValidatorsConsAddrToValAddrCache(tmClient client.Client, stakingQueryCli
 	return &validatorsConsAddrToValAddr{
 		cacheController:             NewBaseCacheController(funcIsExpired),
 		validatorsConsAddrToValAddr: make(map[string]string),
+		validatorsValAddrToMoniker:  make(map[string]string),
 		tmClient:                    tmClient,
 		stakingQueryClient:          stakingQueryClient,
 		codec:                       codec,
@@ -179,6 +196,49 @@ func (vc *validatorsConsAddrToValAddr) GetValAddrFromConsAddr(consAddr string) (
 	return
 }
 
+func (vc *validatorsConsAddrToValAddr) GetValAddrAndMonikerFromConsAddr(consAddr string) (valAddr, moniker string, found bool, err error) {
+	isExpired, errCheckExpired := vc.IsCacheExpired()
+	if errCheckExpired != nil {
+		err = errCheckExpired
+		return
+	}
+
+	lookupUnexpiredData := func() (valAddr, moniker string, found bool) {
+		valAddr, found = vc.validatorsConsAddrToValAddr[consAddr]
+		if found {
+			moniker = vc.validatorsValAddrToMoniker[valAddr]
+		}
+		return
+	}
+
+	if !isExpired {
+		valAddr, moniker, found = lookupUnexpiredData()
+		return
+	}
+
+	vc.cacheController.rwMutex.Lock()
+	defer vc.cacheController.rwMutex.Unlock()
+
+	isExpired, height, errCheckExpired := vc.isCacheExpired(false)
+	if errCheckExpired != nil {
+		err = errCheckExpired
+		return
+	}
+	if !isExpired { // prevent race condition by re-checking after acquiring the lock
+		valAddr, moniker, found = lookupUnexpiredData()
+		return
+	}
+
+	errReloadCache := vc.reloadCacheWithoutLock(height)
+	if errReloadCache != nil {
+		err = errReloadCache
+		return
+	}
+
+	valAddr, moniker, found = lookupUnexpiredData()
+	return
+}
+
 func (vc *validatorsConsAddrToValAddr) GetValAddrAndConsAddr(consOrValAddr string) (valAddr, consAddr string, found bool, err error) {
 	isExpired, errCheckExpired := vc.IsCacheExpired()
 	if errCheckExpired != nil {
@@ -246,30 +306,193 @@ func (vc *validatorsConsAddrToValAddr) isCacheExpired(lock bool) (expired bool,
 }
 
 // reloadCacheWithoutLock performs reload cache. Lock acquire must be performed before calling this.
+// All the validators are loaded, regardless of bonding status.
 func (vc *validatorsConsAddrToValAddr) reloadCacheWithoutLock(height int64) error {
-	var perPage = 200
+	validatorsConsAddrToValAddr := make(map[string]string)
+	validatorsValAddrToMoniker := make(map[string]string)
+
+	var nextKey []byte
+	for {
+		stakingVals, errStakingVals := vc.stakingQueryClient.Validators(context.Background(), &stakingtypes.QueryValidatorsRequest{
+			Status: "", // all statuses
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: validatorsPageSize,
+			},
+		})
+		if errStakingVals != nil {
+			return errStakingVals
+		}
+
+		for _, val := range stakingVals.Validators {
+			consAddr, success := berpcutils.FromAnyPubKeyToConsensusAddress(val.ConsensusPubkey, vc.codec)
+			if !success {
+				continue
+			}
+
+			consAddrStr := consAddr.String()
+			validatorsConsAddrToValAddr[consAddrStr] = val.OperatorAddress
+			validatorsValAddrToMoniker[val.OperatorAddress] = val.Description.Moniker
+		}
+
+		if stakingVals.Pagination == nil || len(stakingVals.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = stakingVals.Pagination.NextKey
+	}
+
+	vc.validatorsConsAddrToValAddr = validatorsConsAddrToValAddr
+	vc.validatorsValAddrToMoniker = validatorsValAddrToMoniker
+	vc.cacheController.UpdateExpirationAnchor(height + validatorsCacheExpiration)
+
+	return nil
+}
+
+// stakingValidatorsCache caches the staking validators of all bonding statuses,
+// and the self-delegation of the validators which were looked up, until the validators are reloaded.
+type stakingValidatorsCache struct {
+	cacheController    *baseCacheController
+	validators         []stakingtypes.Validator
+	selfDelegations    map[string]string
+	tmClient           client.Client
+	stakingQueryClient stakingtypes.QueryClient
+}
 
-	stakingVals, errStakingVals := vc.stakingQueryClient.Validators(context.Background(), &stakingtypes.QueryValidatorsRequest{
-		Pagination: &query.PageRequest{
-			Offset: 0,
-			Limit:  uint64(perPage),
-		},
+// stakingValidatorsCacheExpiration is shorter than validatorsCacheExpiration
+// because the tokens and the jailed status of the validators change frequently.
+const stakingValidatorsCacheExpiration = 10
+
+func NewStakingValidatorsCache(tmClient client.Client, stakingQueryClient stakingtypes.QueryClient) *stakingValidatorsCache {
+	funcIsExpired := func(expirationAnchor, valueToCompare any) bool {
+		return valueToCompare.(int64) > expirationAnchor.(int64)
+	}
+	return &stakingValidatorsCache{
+		cacheController:    NewBaseCacheController(funcIsExpired),
+		selfDelegations:    make(map[string]string),
+		tmClient:           tmClient,
+		stakingQueryClient: stakingQueryClient,
+	}
+}
+
+func (vc *stakingValidatorsCache) GetValidators() (vals []stakingtypes.Validator, err error) {
+	isExpired, errCheckExpired := vc.IsCacheExpired()
+	if errCheckExpired != nil {
+		err = errCheckExpired
+		return
+	}
+
+	if !isExpired {
+		return vc.validators, nil
+	}
+
+	vc.cacheController.rwMutex.Lock()
+	defer vc.cacheController.rwMutex.Unlock()
+
+	isExpired, height, errCheckExpired := vc.isCacheExpired(false)
+	if errCheckExpired != nil {
+		err = errCheckExpired
+		return
+	}
+	if !isExpired { // prevent race condition by re-checking after acquiring the lock
+		return vc.validators, nil
+	}
+
+	errReloadCache := vc.reloadCacheWithoutLock(height)
+	if errReloadCache != nil {
+		err = errReloadCache
+		return
+	}
+
+	return vc.validators, nil
+}
+
+// GetSelfDelegation returns the amount self-delegated by the operator of the validator,
+// "0" if there is no self-delegation remaining.
+func (vc *stakingValidatorsCache) GetSelfDelegation(valAddr sdk.ValAddress) (amount string, err error) {
+	valAddrStr := valAddr.String()
+
+	vc.cacheController.rwMutex.RLock()
+	amount, found := vc.selfDelegations[valAddrStr]
+	vc.cacheController.rwMutex.RUnlock()
+	if found {
+		return
+	}
+
+	resDelegation, errDelegation := vc.stakingQueryClient.Delegation(context.Background(), &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: sdk.AccAddress(valAddr).String(),
+		ValidatorAddr: valAddrStr,
 	})
-	if errStakingVals != nil {
-		return errStakingVals
+	if errDelegation != nil {
+		if status.Code(errDelegation) != codes.NotFound {
+			err = errDelegation
+			return
+		}
+
+		// no self-delegation remaining
+		amount = "0"
+	} else if resDelegation.DelegationResponse == nil {
+		amount = "0"
+	} else {
+		amount = resDelegation.DelegationResponse.Balance.Amount.String()
+	}
+
+	vc.cacheController.rwMutex.Lock()
+	vc.selfDelegations[valAddrStr] = amount
+	vc.cacheController.rwMutex.Unlock()
+
+	return
+}
+
+func (vc *stakingValidatorsCache) IsCacheExpired() (expired bool, err error) {
+	expired, _, err = vc.isCacheExpired(true)
+	return
+}
+
+func (vc *stakingValidatorsCache) isCacheExpired(lock bool) (expired bool, latestHeight int64, err error) {
+	resStatus, err := vc.tmClient.Status(context.Background())
+	if err != nil {
+		return false, 0, err
+	}
+
+	if lock {
+		vc.cacheController.rwMutex.Lock()
+		defer vc.cacheController.rwMutex.Unlock()
 	}
 
-	for _, val := range stakingVals.Validators {
-		consAddr, success := berpcutils.FromAnyPubKeyToConsensusAddress(val.ConsensusPubkey, vc.codec)
-		if !success {
-			continue
+	latestHeight = resStatus.SyncInfo.LatestBlockHeight
+	expired = vc.cacheController.IsExpired(latestHeight)
+	return
+}
+
+// reloadCacheWithoutLock performs reload cache. Lock acquire must be performed before calling this.
+// All the validators are loaded, regardless of bonding status, the self-delegations are cleared.
+func (vc *stakingValidatorsCache) reloadCacheWithoutLock(height int64) error {
+	var validators []stakingtypes.Validator
+
+	var nextKey []byte
+	for {
+		stakingVals, errStakingVals := vc.stakingQueryClient.Validators(context.Background(), &stakingtypes.QueryValidatorsRequest{
+			Status: "", // all statuses
+			Pagination: &query.PageRequest{
+				Key:   nextKey,
+				Limit: validatorsPageSize,
+			},
+		})
+		if errStakingVals != nil {
+			return errStakingVals
 		}
 
-		consAddrStr := consAddr.String()
-		vc.validatorsConsAddrToValAddr[consAddrStr] = val.OperatorAddress
+		validators = append(validators, stakingVals.Validators...)
+
+		if stakingVals.Pagination == nil || len(stakingVals.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = stakingVals.Pagination.NextKey
 	}
 
-	vc.cacheController.UpdateExpirationAnchor(height + validatorsCacheExpiration)
+	vc.validators = validators
+	vc.selfDelegations = make(map[string]string)
+	vc.cacheController.UpdateExpirationAnchor(height + stakingValidatorsCacheExpiration)
 
 	return nil
 }
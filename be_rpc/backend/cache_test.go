@@ -0,0 +1,314 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"testing"
+)
+
+// fakeTendermintClient serves the validator set with pagination, other methods are not implemented.
+type fakeTendermintClient struct {
+	client.Client
+	height     int64
+	validators []*tmtypes.Validator
+	calls      int
+}
+
+func (c *fakeTendermintClient) Status(_ context.Context) (*coretypes.ResultStatus, error) {
+	return &coretypes.ResultStatus{
+		SyncInfo: coretypes.SyncInfo{
+			LatestBlockHeight: c.height,
+		},
+	}, nil
+}
+
+func (c *fakeTendermintClient) Validators(_ context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
+	c.calls++
+
+	if *perPage > 100 {
+		*perPage = 100
+	}
+	start := (*page - 1) * *perPage
+	if start > len(c.validators) {
+		return nil, fmt.Errorf("page should be within [1, %d] range, given %d", (len(c.validators)+*perPage-1) / *perPage, *page)
+	}
+	end := start + *perPage
+	if end > len(c.validators) {
+		end = len(c.validators)
+	}
+
+	return &coretypes.ResultValidators{
+		BlockHeight: *height,
+		Validators:  c.validators[start:end],
+		Count:       end - start,
+		Total:       len(c.validators),
+	}, nil
+}
+
+// fakeStakingQueryClient serves the staking validators with key-based pagination and the delegations,
+// other methods are not implemented.
+type fakeStakingQueryClient struct {
+	stakingtypes.QueryClient
+	validators      []stakingtypes.Validator
+	calls           int
+	delegations     map[string]sdk.Coin // by validator address, self-delegation only
+	delegationErr   error
+	delegationCalls int
+}
+
+func (c *fakeStakingQueryClient) Delegation(_ context.Context, req *stakingtypes.QueryDelegationRequest, _ ...grpc.CallOption) (*stakingtypes.QueryDelegationResponse, error) {
+	c.delegationCalls++
+
+	if c.delegationErr != nil {
+		return nil, c.delegationErr
+	}
+
+	balance, found := c.delegations[req.ValidatorAddr]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "delegation with delegator %s not found for validator %s", req.DelegatorAddr, req.ValidatorAddr)
+	}
+
+	return &stakingtypes.QueryDelegationResponse{
+		DelegationResponse: &stakingtypes.DelegationResponse{
+			Delegation: stakingtypes.Delegation{
+				DelegatorAddress: req.DelegatorAddr,
+				ValidatorAddress: req.ValidatorAddr,
+			},
+			Balance: balance,
+		},
+	}, nil
+}
+
+func (c *fakeStakingQueryClient) Validators(_ context.Context, req *stakingtypes.QueryValidatorsRequest, _ ...grpc.CallOption) (*stakingtypes.QueryValidatorsResponse, error) {
+	c.calls++
+
+	var validators []stakingtypes.Validator
+	for _, validator := range c.validators {
+		if req.Status == "" || req.Status == validator.Status.String() {
+			validators = append(validators, validator)
+		}
+	}
+
+	start := 0
+	if len(req.Pagination.Key) > 0 {
+		var err error
+		start, err = strconv.Atoi(string(req.Pagination.Key))
+		if err != nil {
+			return nil, err
+		}
+	}
+	end := start + int(req.Pagination.Limit)
+	if end > len(validators) {
+		end = len(validators)
+	}
+
+	var nextKey []byte
+	if end < len(validators) {
+		nextKey = []byte(strconv.Itoa(end))
+	}
+
+	return &stakingtypes.QueryValidatorsResponse{
+		Validators: validators[start:end],
+		Pagination: &query.PageResponse{
+			NextKey: nextKey,
+		},
+	}, nil
+}
+
+func newTestCodec() codec.Codec {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	return codec.NewProtoCodec(interfaceRegistry)
+}
+
+func newTestTendermintValidators(count int) []*tmtypes.Validator {
+	validators := make([]*tmtypes.Validator, count)
+	for i := range validators {
+		validators[i] = tmtypes.NewValidator(tmed25519.GenPrivKey().PubKey(), int64(count-i))
+	}
+	return validators
+}
+
+func newTestStakingValidators(t *testing.T, count int) []stakingtypes.Validator {
+	statuses := []stakingtypes.BondStatus{stakingtypes.Bonded, stakingtypes.Unbonding, stakingtypes.Unbonded}
+
+	validators := make([]stakingtypes.Validator, count)
+	for i := range validators {
+		pubKey := ed25519.GenPrivKey().PubKey()
+		pubKeyAny, err := codectypes.NewAnyWithValue(pubKey)
+		require.NoError(t, err)
+
+		validators[i] = stakingtypes.Validator{
+			OperatorAddress: sdk.ValAddress(pubKey.Address()).String(),
+			ConsensusPubkey: pubKeyAny,
+			Status:          statuses[i%len(statuses)],
+			Description: stakingtypes.Description{
+				Moniker: fmt.Sprintf("validator-%d", i),
+			},
+		}
+	}
+	return validators
+}
+
+func TestTendermintValidatorsCache_GetValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		wantCalls int
+	}{
+		{name: "single page", count: 5, wantCalls: 1},
+		{name: "exactly one full page", count: 100, wantCalls: 1},
+		{name: "multiple pages", count: 250, wantCalls: 3},
+		{name: "empty", count: 0, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmClient := &fakeTendermintClient{
+				height:     10,
+				validators: newTestTendermintValidators(tt.count),
+			}
+
+			cache := NewTendermintValidatorsCache(tmClient)
+
+			validators, err := cache.GetValidators()
+			require.NoError(t, err)
+			require.Len(t, validators, tt.count)
+			for i, validator := range validators {
+				require.Equal(t, tmClient.validators[i].Address, validator.Address)
+			}
+			require.Equal(t, tt.wantCalls, tmClient.calls)
+
+			// served from cache
+			_, err = cache.GetValidators()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCalls, tmClient.calls)
+		})
+	}
+}
+
+func TestValidatorsConsAddrToValAddr(t *testing.T) {
+	validators := newTestStakingValidators(t, 250)
+	tmClient := &fakeTendermintClient{height: 10}
+	stakingQueryClient := &fakeStakingQueryClient{validators: validators}
+	cdc := newTestCodec()
+
+	cache := NewValidatorsConsAddrToValAddrCache(tmClient, stakingQueryClient, cdc)
+
+	for i, validator := range validators {
+		var pubKey cryptotypes.PubKey
+		require.NoError(t, cdc.UnpackAny(validator.ConsensusPubkey, &pubKey))
+		consAddr := sdk.ConsAddress(pubKey.Address()).String()
+
+		valAddr, moniker, found, err := cache.GetValAddrAndMonikerFromConsAddr(consAddr)
+		require.NoError(t, err)
+		require.True(t, found, "validator %d with status %s must be found", i, validator.Status)
+		require.Equal(t, validator.OperatorAddress, valAddr)
+		require.Equal(t, validator.Description.Moniker, moniker)
+
+		valAddr, foundConsAddr, found, err := cache.GetValAddrAndConsAddr(validator.OperatorAddress)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, validator.OperatorAddress, valAddr)
+		require.Equal(t, consAddr, foundConsAddr)
+	}
+
+	require.Equal(t, 3, stakingQueryClient.calls, "all pages must be loaded once")
+
+	_, found, err := cache.GetValAddrFromConsAddr(sdk.ConsAddress(ed25519.GenPrivKey().PubKey().Address()).String())
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestStakingValidatorsCache(t *testing.T) {
+	validators := newTestStakingValidators(t, 250)
+	tmClient := &fakeTendermintClient{height: 10}
+	stakingQueryClient := &fakeStakingQueryClient{
+		validators: validators,
+		delegations: map[string]sdk.Coin{
+			validators[0].OperatorAddress: sdk.NewInt64Coin("stake", 1000),
+		},
+	}
+
+	cache := NewStakingValidatorsCache(tmClient, stakingQueryClient)
+
+	t.Run("validators of all statuses are loaded once", func(t *testing.T) {
+		gotValidators, err := cache.GetValidators()
+		require.NoError(t, err)
+		require.Equal(t, validators, gotValidators)
+		require.Equal(t, 3, stakingQueryClient.calls)
+
+		_, err = cache.GetValidators()
+		require.NoError(t, err)
+		require.Equal(t, 3, stakingQueryClient.calls, "must be served from cache")
+	})
+
+	valAddr := func(i int) sdk.ValAddress {
+		valAddr, err := sdk.ValAddressFromBech32(validators[i].OperatorAddress)
+		require.NoError(t, err)
+		return valAddr
+	}
+
+	t.Run("self-delegation", func(t *testing.T) {
+		amount, err := cache.GetSelfDelegation(valAddr(0))
+		require.NoError(t, err)
+		require.Equal(t, "1000", amount)
+
+		amount, err = cache.GetSelfDelegation(valAddr(1))
+		require.NoError(t, err)
+		require.Equal(t, "0", amount, "not found means no self-delegation remaining")
+
+		require.Equal(t, 2, stakingQueryClient.delegationCalls)
+
+		_, err = cache.GetSelfDelegation(valAddr(0))
+		require.NoError(t, err)
+		_, err = cache.GetSelfDelegation(valAddr(1))
+		require.NoError(t, err)
+		require.Equal(t, 2, stakingQueryClient.delegationCalls, "must be served from cache")
+	})
+
+	t.Run("self-delegation query error is returned", func(t *testing.T) {
+		stakingQueryClient.delegationErr = status.Error(codes.Unavailable, "connection refused")
+		defer func() {
+			stakingQueryClient.delegationErr = nil
+		}()
+
+		_, err := cache.GetSelfDelegation(valAddr(2))
+		require.Error(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+
+		stakingQueryClient.delegationErr = nil
+		amount, err := cache.GetSelfDelegation(valAddr(2))
+		require.NoError(t, err)
+		require.Equal(t, "0", amount, "error must not be cached")
+	})
+
+	t.Run("self-delegations are cleared on reload", func(t *testing.T) {
+		tmClient.height += stakingValidatorsCacheExpiration + 1
+		stakingQueryClient.delegations[validators[1].OperatorAddress] = sdk.NewInt64Coin("stake", 1)
+
+		_, err := cache.GetValidators()
+		require.NoError(t, err)
+		require.Equal(t, 6, stakingQueryClient.calls, "must be reloaded")
+
+		amount, err := cache.GetSelfDelegation(valAddr(1))
+		require.NoError(t, err)
+		require.Equal(t, "1", amount)
+	})
+}
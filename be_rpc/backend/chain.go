@@ -6,6 +6,7 @@ import (
 	disttypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -110,6 +111,14 @@ func (m *Backend) GetModuleParams(moduleName string) (berpctypes.GenericBackendR
 			params = mintParams.Params
 		}
 		break
+	case "slashing":
+		slashingParams, errFetch := m.queryClient.SlashingQueryClient.Params(m.ctx, &slashingtypes.QueryParamsRequest{})
+		if errFetch != nil {
+			err = errors.Wrap(errFetch, "failed to get slashing params")
+		} else {
+			params = slashingParams.Params
+		}
+		break
 	default:
 		err = errors.Errorf("not yet support module %s", moduleName)
 		break
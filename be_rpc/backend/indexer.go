@@ -0,0 +1,248 @@
+package backend
+
+import (
+	"cosmossdk.io/errors"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/config"
+	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/indexer"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"path/filepath"
+	"strings"
+)
+
+var _ indexer.DataSource = (*indexerDataSource)(nil)
+
+// indexerDataSource provides the blocks information, with involvers extracted by the registered extractors, to the indexer.
+type indexerDataSource struct {
+	backend *Backend
+}
+
+// indexedTxSummary is the summary of a transaction, stored in the index to serve the queries
+// without fetching the block from the node. Fields are the same as the output of getTransactionSummary.
+type indexedTxSummary struct {
+	Hash              string                            `json:"hash"`
+	Type              string                            `json:"type"`
+	Involvers         berpctypes.MessageInvolversResult `json:"involvers"`
+	MessagesType      []string                          `json:"messagesType"`
+	MessagesInvolvers []berpctypes.MessageInvolverRoles `json:"messagesInvolvers"`
+}
+
+func newIndexedTxSummary(txInfo map[string]any) indexedTxSummary {
+	summary := indexedTxSummary{}
+	summary.Hash, _ = txInfo["hash"].(string)
+	summary.Type, _ = txInfo["type"].(string)
+	summary.Involvers, _ = txInfo["involvers"].(berpctypes.MessageInvolversResult)
+	summary.MessagesType, _ = txInfo["messagesType"].([]string)
+	summary.MessagesInvolvers, _ = txInfo["messagesInvolvers"].([]berpctypes.MessageInvolverRoles)
+	return summary
+}
+
+func (s indexedTxSummary) toMap() map[string]any {
+	return map[string]any{
+		"hash":              s.Hash,
+		"type":              s.Type,
+		"involvers":         s.Involvers,
+		"messagesType":      s.MessagesType,
+		"messagesInvolvers": s.MessagesInvolvers,
+	}
+}
+
+func (s *indexerDataSource) GetAvailableBlockRange() (earliest, latest int64, err error) {
+	statusInfo, err := s.backend.clientCtx.Client.Status(s.backend.ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return statusInfo.SyncInfo.EarliestBlockHeight, statusInfo.SyncInfo.LatestBlockHeight, nil
+}
+
+func (s *indexerDataSource) GetIndexedTxsInBlock(height int64) ([]indexer.IndexedTx, error) {
+	m := s.backend
+
+	resBlock, err := m.clientCtx.Client.Block(m.ctx, &height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block")
+	}
+
+	if len(resBlock.Block.Txs) == 0 {
+		return nil, nil
+	}
+
+	resBlockResults, err := m.clientCtx.Client.BlockResults(m.ctx, &height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block results")
+	}
+
+	indexedTxs := make([]indexer.IndexedTx, 0, len(resBlock.Block.Txs))
+	for txIdx, tmTx := range resBlock.Block.Txs {
+		txHash := strings.ToUpper(hex.EncodeToString(tmTx.Hash()))
+
+		tx, err := m.decodeTx(tmTx)
+		if err != nil {
+			// not able to decode, can not extract involvers
+			m.GetLogger().Error("failed to decode transaction for indexing", "height", height, "index", txIdx, "hash", txHash, "error", err)
+			indexedTxs = append(indexedTxs, indexer.IndexedTx{
+				TxIndex:     uint32(txIdx),
+				Hash:        txHash,
+				Undecodable: true,
+			})
+			continue
+		}
+
+		var txEvents []abci.Event
+		if txIdx < len(resBlockResults.TxsResults) {
+			txEvents = resBlockResults.TxsResults[txIdx].Events
+		}
+
+		txInfo, err := m.getTransactionSummary(tx, tmTx, txEvents, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unpack message")
+		}
+
+		summary := newIndexedTxSummary(txInfo)
+
+		var involvers []string
+		for _, addresses := range summary.Involvers {
+			for _, address := range addresses {
+				involvers = append(involvers, m.bech32Cfg.FromAnyToBech32AccountAddrUnsafe(address))
+			}
+		}
+
+		var senders, recipients []string
+		for _, messageInvolvers := range summary.MessagesInvolvers {
+			for address, roles := range messageInvolvers {
+				for _, role := range roles {
+					switch role {
+					case berpctypes.InvolverRoleSender:
+						senders = append(senders, m.bech32Cfg.FromAnyToBech32AccountAddrUnsafe(address))
+					case berpctypes.InvolverRoleRecipient:
+						recipients = append(recipients, m.bech32Cfg.FromAnyToBech32AccountAddrUnsafe(address))
+					}
+				}
+			}
+		}
+
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal transaction summary")
+		}
+
+		indexedTxs = append(indexedTxs, indexer.IndexedTx{
+			TxIndex:    uint32(txIdx),
+			Hash:       txHash,
+			Involvers:  involvers,
+			Senders:    senders,
+			Recipients: recipients,
+			Data:       data,
+		})
+	}
+
+	return indexedTxs, nil
+}
+
+// StartIndexer opens the index database within the data directory of the given home directory
+// and starts the background worker indexing transactions by involved addresses.
+func (m *Backend) StartIndexer(homeDir string) error {
+	if m.indexer != nil {
+		return nil
+	}
+
+	db, err := dbm.NewDB(indexer.DbName, dbm.GoLevelDBBackend, filepath.Join(homeDir, config.DefaultDataDirName))
+	if err != nil {
+		return errors.Wrap(err, "failed to open indexer database")
+	}
+
+	idx, err := indexer.NewIndexer(db, &indexerDataSource{backend: m}, m.logger.With("module", "be_indexer"))
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	idx.Start()
+	m.indexer = idx
+	return nil
+}
+
+// StopIndexer stops the background worker of the indexer, if started, and closes the index database.
+func (m *Backend) StopIndexer() error {
+	if m.indexer == nil {
+		return nil
+	}
+
+	return m.indexer.Close()
+}
+
+// isIndexerCaughtUp returns true if the indexer is enabled and has indexed up to the latest block once,
+// otherwise the index is incomplete and can not be used to serve the queries.
+func (m *Backend) isIndexerCaughtUp() bool {
+	return m.indexer != nil && m.indexer.GetStatus().CaughtUp
+}
+
+func (m *Backend) GetIndexerStatus() (berpctypes.GenericBackendResponse, error) {
+	if m.indexer == nil {
+		return berpctypes.GenericBackendResponse{
+			"enabled": false,
+		}, nil
+	}
+
+	indexerStatus := m.indexer.GetStatus()
+
+	res := berpctypes.GenericBackendResponse{
+		"enabled":            true,
+		"firstIndexedHeight": indexerStatus.FirstIndexedHeight,
+		"lastIndexedHeight":  indexerStatus.LastIndexedHeight,
+		"latestBlockHeight":  indexerStatus.LatestBlockHeight,
+		"catchingUp":         indexerStatus.LastIndexedHeight < indexerStatus.LatestBlockHeight,
+		"caughtUp":           indexerStatus.CaughtUp,
+		"undecodableTxs":     indexerStatus.UndecodableTxs,
+	}
+
+	if indexerStatus.LastError != "" {
+		res["lastError"] = indexerStatus.LastError
+	}
+
+	return res, nil
+}
+
+// getTransactionsByAccountFromIndexer returns the page of transactions involving the account, served from the index,
+// and the total number of transactions involving the account.
+// The rows are built from the summary stored in the index, so the blocks pruned from the node are still served.
+func (m *Backend) getTransactionsByAccountFromIndexer(accAddrStr string, pageNo int) ([]map[string]any, int, error) {
+	txRefs, total, err := m.indexer.GetTransactionsByAddress(accAddrStr, pageNo, defaultPageSize)
+	if err != nil {
+		if errors.IsOf(err, indexer.ErrClosed) {
+			return nil, 0, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, 0, status.Error(codes.Internal, errors.Wrap(err, "failed to query indexer").Error())
+	}
+
+	txsInfo := make([]map[string]any, 0, len(txRefs))
+	for _, txRef := range txRefs {
+		txsInfo = append(txsInfo, indexedTxRefToMap(txRef))
+	}
+
+	return txsInfo, int(total), nil
+}
+
+// indexedTxRefToMap returns the transaction summary stored in the index,
+// degrades to the hash and height only if the summary is missing or malformed.
+func indexedTxRefToMap(txRef indexer.IndexedTxRef) map[string]any {
+	var txInfo map[string]any
+
+	var summary indexedTxSummary
+	if len(txRef.Data) > 0 && json.Unmarshal(txRef.Data, &summary) == nil {
+		txInfo = summary.toMap()
+	} else {
+		txInfo = map[string]any{
+			"hash": txRef.Hash,
+		}
+	}
+
+	txInfo["height"] = txRef.Height
+	return txInfo
+}
@@ -0,0 +1,48 @@
+package backend
+
+import (
+	"encoding/json"
+	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/indexer"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_indexedTxRefToMap(t *testing.T) {
+	txInfo := map[string]any{
+		"hash": "0xEVMHASH",
+		"type": "evm",
+		"involvers": berpctypes.MessageInvolversResult{
+			berpctypes.MessageInvolvers: []string{"addr1"},
+		},
+		"messagesType": []string{"/ethermint.evm.v1.MsgEthereumTx"},
+		"messagesInvolvers": []berpctypes.MessageInvolverRoles{
+			{"addr1": {berpctypes.InvolverRoleSender}},
+		},
+	}
+
+	data, err := json.Marshal(newIndexedTxSummary(txInfo))
+	require.NoError(t, err)
+
+	t.Run("served from the stored summary", func(t *testing.T) {
+		got := indexedTxRefToMap(indexer.IndexedTxRef{Height: 10, TxIndex: 1, Hash: "TMHASH", Data: data})
+
+		want := map[string]any{"height": int64(10)}
+		for k, v := range txInfo {
+			want[k] = v
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("degrades to hash and height without summary", func(t *testing.T) {
+		require.Equal(t, map[string]any{
+			"hash":   "TMHASH",
+			"height": int64(10),
+		}, indexedTxRefToMap(indexer.IndexedTxRef{Height: 10, TxIndex: 1, Hash: "TMHASH"}))
+
+		require.Equal(t, map[string]any{
+			"hash":   "TMHASH",
+			"height": int64(10),
+		}, indexedTxRefToMap(indexer.IndexedTxRef{Height: 10, TxIndex: 1, Hash: "TMHASH", Data: []byte("{")}))
+	})
+}
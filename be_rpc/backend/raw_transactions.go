@@ -0,0 +1,318 @@
+package backend
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"regexp"
+	"strings"
+)
+
+var patternHexBytes = regexp.MustCompile(`^(0[xX])?([\da-fA-F]{2})+$`)
+
+func (m *Backend) DecodeTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error) {
+	txBytes, err := decodeTxBytes(base64OrHexTxBytes)
+	if err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	tx, err := m.decodeTx(txBytes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "failed to decode transaction").Error())
+	}
+
+	if err := validateDecodedTx(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tmTx := tmtypes.Tx(txBytes)
+	txHash := strings.ToUpper(hex.EncodeToString(tmTx.Hash()))
+
+	msgsInfo, err := m.parseMessages(tx, &sdk.TxResponse{
+		TxHash: txHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txSummary, err := m.getTransactionSummary(tx, tmTx, nil, false)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unpack message").Error())
+	}
+
+	response := berpctypes.GenericBackendResponse{
+		"hash":      txHash,
+		"msgs":      msgsInfo,
+		"involvers": txSummary["involvers"],
+		"authInfo":  m.getAuthInfo(tx),
+	}
+
+	if len(tx.Body.Memo) > 0 {
+		response["memo"] = tx.Body.Memo
+	}
+
+	return response, nil
+}
+
+func (m *Backend) SimulateTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error) {
+	txBytes, err := decodeTxBytes(base64OrHexTxBytes)
+	if err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	tx, err := m.decodeTx(txBytes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "failed to decode transaction").Error())
+	}
+
+	// the simulation errors returned via ABCI lost their type, so the malformed transaction is rejected here
+	if err := validateDecodedTx(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := m.validateTxMessages(tx); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resSimulate, err := m.queryClient.Simulate(m.ctx, &txtypes.SimulateRequest{
+		TxBytes: txBytes,
+	})
+	if err != nil {
+		return nil, status.Error(simulationErrorCode(err), errors.Wrap(err, "failed to simulate transaction").Error())
+	}
+
+	var gasUsed, gasWanted uint64
+	if resSimulate.GasInfo != nil {
+		gasUsed = resSimulate.GasInfo.GasUsed
+		gasWanted = resSimulate.GasInfo.GasWanted
+	}
+
+	var events []abci.Event
+	if resSimulate.Result != nil {
+		events = resSimulate.Result.Events
+	}
+
+	txHash := strings.ToUpper(hex.EncodeToString(tmtypes.Tx(txBytes).Hash()))
+
+	msgsInfo, err := m.parseMessages(tx, &sdk.TxResponse{
+		TxHash:    txHash,
+		GasUsed:   int64(gasUsed),
+		GasWanted: int64(gasWanted),
+		Events:    events,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"hash": txHash,
+		"msgs": msgsInfo,
+		"gas": berpctypes.GenericBackendResponse{
+			"used":   gasUsed,
+			"wanted": gasWanted,
+		},
+		"events": berpctypes.ConvertTxEvent(events).RemoveUnnecessaryEvmTxEvents(),
+	}, nil
+}
+
+// simulationErrorCode returns the gRPC code for the simulation error:
+//   - Internal for the transport errors.
+//   - InvalidArgument for the malformed transaction rejected by the tx service.
+//   - FailedPrecondition for the transaction failed to execute, like out of gas or rejected by the ante handlers.
+func simulationErrorCode(err error) codes.Code {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.Internal, codes.ResourceExhausted, codes.Unimplemented:
+		return codes.Internal
+	case codes.InvalidArgument:
+		return codes.InvalidArgument
+	default:
+		return codes.FailedPrecondition
+	}
+}
+
+var broadcastModes = map[string]txtypes.BroadcastMode{
+	"sync":   txtypes.BroadcastMode_BROADCAST_MODE_SYNC,
+	"async":  txtypes.BroadcastMode_BROADCAST_MODE_ASYNC,
+	"block":  txtypes.BroadcastMode_BROADCAST_MODE_BLOCK,
+	"commit": txtypes.BroadcastMode_BROADCAST_MODE_BLOCK,
+}
+
+func (m *Backend) BroadcastTransaction(base64OrHexTxBytes string, mode string) (berpctypes.GenericBackendResponse, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if len(mode) == 0 {
+		mode = "sync"
+	}
+	broadcastMode, found := broadcastModes[mode]
+	if !found {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	txBytes, err := decodeTxBytes(base64OrHexTxBytes)
+	if err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	tx, err := m.decodeTx(txBytes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "failed to decode transaction").Error())
+	}
+
+	resBroadcast, err := m.queryClient.BroadcastTx(m.ctx, &txtypes.BroadcastTxRequest{
+		TxBytes: txBytes,
+		Mode:    broadcastMode,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to broadcast transaction").Error())
+	}
+	if resBroadcast == nil || resBroadcast.TxResponse == nil {
+		return nil, status.Error(codes.Internal, "empty broadcast response")
+	}
+
+	txRes := resBroadcast.TxResponse
+
+	response := berpctypes.GenericBackendResponse{
+		"hash":      txRes.TxHash,
+		"mode":      mode,
+		"code":      txRes.Code,
+		"codespace": txRes.Codespace,
+		"success":   txRes.Code == 0,
+	}
+	if txRes.Code != 0 {
+		response["rawLog"] = txRes.RawLog
+	}
+
+	// the transaction had been included into a block, so the result is available
+	if broadcastMode == txtypes.BroadcastMode_BROADCAST_MODE_BLOCK && txRes.Height > 0 {
+		response["height"] = txRes.Height
+
+		txResponse, err := m.buildTransactionResponse(tx, txRes)
+		if err != nil {
+			response["txError"] = err.Error()
+		} else {
+			response["tx"] = txResponse
+		}
+	}
+
+	return response, nil
+}
+
+func (m *Backend) GetUnconfirmedTransactions(limit int) (berpctypes.GenericBackendResponse, error) {
+	const maxLimit = 100
+
+	if limit < 1 {
+		return nil, berpctypes.ErrBadPageSize
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	resUnconfirmedTxs, err := m.clientCtx.Client.UnconfirmedTxs(m.ctx, &limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get unconfirmed transactions").Error())
+	}
+
+	txsInfo := make([]map[string]any, 0)
+	for _, tmTx := range resUnconfirmedTxs.Txs {
+		tx, err := m.decodeTx(tmTx)
+		if err != nil {
+			txsInfo = append(txsInfo, map[string]any{
+				"hash":        strings.ToUpper(hex.EncodeToString(tmTx.Hash())),
+				"decodeError": err.Error(),
+			})
+			continue
+		}
+
+		txInfo, err := m.getTransactionSummary(tx, tmTx, nil, false)
+		if err != nil {
+			txsInfo = append(txsInfo, map[string]any{
+				"hash":        strings.ToUpper(hex.EncodeToString(tmTx.Hash())),
+				"decodeError": err.Error(),
+			})
+			continue
+		}
+
+		txsInfo = append(txsInfo, txInfo)
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"txs":        txsInfo,
+		"count":      resUnconfirmedTxs.Count,
+		"total":      resUnconfirmedTxs.Total,
+		"totalBytes": resUnconfirmedTxs.TotalBytes,
+	}, nil
+}
+
+// validateDecodedTx performs the basic validation on the transaction decoded from the user provided bytes,
+// to reject the malformed transactions which can not be parsed.
+func validateDecodedTx(tx *txtypes.Tx) error {
+	if tx.Body == nil || len(tx.Body.Messages) == 0 {
+		return errors.New("transaction has no message")
+	}
+
+	if tx.AuthInfo != nil && tx.AuthInfo.Fee != nil {
+		if payer := tx.AuthInfo.Fee.Payer; len(payer) > 0 {
+			if _, err := sdk.AccAddressFromBech32(payer); err != nil {
+				return errors.Wrap(err, "invalid fee payer")
+			}
+		}
+		if granter := tx.AuthInfo.Fee.Granter; len(granter) > 0 {
+			if _, err := sdk.AccAddressFromBech32(granter); err != nil {
+				return errors.Wrap(err, "invalid fee granter")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateTxMessages unpacks the messages of the transaction and performs the stateless validation on each of them.
+func (m *Backend) validateTxMessages(tx *txtypes.Tx) error {
+	for msgIdx, msg := range tx.Body.Messages {
+		var cosmosMsg sdk.Msg
+		if err := m.clientCtx.Codec.UnpackAny(msg, &cosmosMsg); err != nil {
+			return errors.Wrapf(err, "failed to unpack message %d", msgIdx)
+		}
+
+		if err := validateBasicMessage(cosmosMsg); err != nil {
+			return errors.Wrapf(err, "invalid message %d", msgIdx)
+		}
+	}
+
+	return nil
+}
+
+// validateBasicMessage calls ValidateBasic of the message, recovers if it panics on the malformed message.
+func validateBasicMessage(msg sdk.Msg) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic during validation: %v", r)
+		}
+	}()
+
+	return msg.ValidateBasic()
+}
+
+// decodeTxBytes decodes the transaction bytes from either hex (with or without 0x prefix) or base64 format.
+func decodeTxBytes(base64OrHexTxBytes string) ([]byte, error) {
+	input := strings.TrimSpace(base64OrHexTxBytes)
+	if len(input) == 0 {
+		return nil, errors.New("empty input")
+	}
+
+	if patternHexBytes.MatchString(input) {
+		if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+			input = input[2:]
+		}
+		return hex.DecodeString(input)
+	}
+
+	return base64.StdEncoding.DecodeString(input)
+}
@@ -0,0 +1,172 @@
+package backend
+
+import (
+	"encoding/hex"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestBackend_DecodeTransaction_BadInput(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	m := &Backend{
+		clientCtx: client.Context{
+			Codec:    cdc,
+			TxConfig: authtx.NewTxConfig(cdc, authtx.DefaultSignModes),
+		},
+		logger: log.NewNopLogger(),
+	}
+
+	msgAny, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+		FromAddress: sdk.AccAddress("from").String(),
+		ToAddress:   sdk.AccAddress("to").String(),
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+	})
+	require.NoError(t, err)
+
+	encodeTx := func(messages []*codectypes.Any, fee *tx.Fee) string {
+		bodyBytes, err := cdc.Marshal(&tx.TxBody{
+			Messages: messages,
+		})
+		require.NoError(t, err)
+
+		authInfoBytes, err := cdc.Marshal(&tx.AuthInfo{
+			Fee: fee,
+		})
+		require.NoError(t, err)
+
+		txBytes, err := cdc.Marshal(&tx.TxRaw{
+			BodyBytes:     bodyBytes,
+			AuthInfoBytes: authInfoBytes,
+		})
+		require.NoError(t, err)
+
+		return hex.EncodeToString(txBytes)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "not transaction bytes",
+			input:   "0x1234",
+			wantErr: "failed to decode transaction",
+		},
+		{
+			name:    "no message",
+			input:   encodeTx(nil, &tx.Fee{}),
+			wantErr: "transaction has no message",
+		},
+		{
+			name:    "bad fee payer",
+			input:   encodeTx([]*codectypes.Any{msgAny}, &tx.Fee{Payer: "bad"}),
+			wantErr: "invalid fee payer",
+		},
+		{
+			name:    "bad fee granter",
+			input:   encodeTx([]*codectypes.Any{msgAny}, &tx.Fee{Granter: "bad"}),
+			wantErr: "invalid fee granter",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				_, err := m.DecodeTransaction(tt.input)
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+				require.Contains(t, err.Error(), tt.wantErr)
+			})
+		})
+	}
+
+	t.Run("simulate", func(t *testing.T) {
+		badMsgAny, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+			FromAddress: "bad",
+			ToAddress:   sdk.AccAddress("to").String(),
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+		})
+		require.NoError(t, err)
+
+		zeroAmountMsgAny, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+			FromAddress: sdk.AccAddress("from").String(),
+			ToAddress:   sdk.AccAddress("to").String(),
+		})
+		require.NoError(t, err)
+
+		simulateTests := append(tests, []struct {
+			name    string
+			input   string
+			wantErr string
+		}{
+			{
+				name:    "bad message address",
+				input:   encodeTx([]*codectypes.Any{msgAny, badMsgAny}, &tx.Fee{}),
+				wantErr: "invalid message 1",
+			},
+			{
+				name:    "bad message amount",
+				input:   encodeTx([]*codectypes.Any{zeroAmountMsgAny}, &tx.Fee{}),
+				wantErr: "invalid message 0",
+			},
+		}...)
+		for _, tt := range simulateTests {
+			t.Run(tt.name, func(t *testing.T) {
+				// rejected before simulation, the query client is not set
+				require.NotPanics(t, func() {
+					_, err := m.SimulateTransaction(tt.input)
+					require.Error(t, err)
+					require.Equal(t, codes.InvalidArgument, status.Code(err))
+					require.Contains(t, err.Error(), tt.wantErr)
+				})
+			})
+		}
+	})
+}
+
+func Test_simulationErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{
+			name: "transport error",
+			err:  status.Error(codes.Unavailable, "connection refused"),
+			want: codes.Internal,
+		},
+		{
+			name: "timeout",
+			err:  status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			want: codes.Internal,
+		},
+		{
+			name: "bad request rejected by tx service",
+			err:  status.Error(codes.InvalidArgument, "invalid tx"),
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "execution failure returned by tx service",
+			err:  status.Error(codes.Unknown, "out of gas With gas wanted: '1' and gas used: '2'"),
+			want: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, simulationErrorCode(tt.err))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package backend
+
+import (
+	"encoding/hex"
+	"fmt"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1types "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/ethereum/go-ethereum/common"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	searchResultTypeBlock       = "block"
+	searchResultTypeTransaction = "transaction"
+	searchResultTypeAccount     = "account"
+	searchResultTypeValidator   = "validator"
+	searchResultTypeProposal    = "proposal"
+	searchResultTypeDenom       = "denom"
+)
+
+var patternNumber = regexp.MustCompile(`^\d+$`)
+
+func (m *Backend) Search(query string) (berpctypes.GenericBackendResponse, error) {
+	query = strings.TrimSpace(query)
+	if len(query) == 0 {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	results := make([]map[string]any, 0)
+	addResult := func(resultType, id string, summary map[string]any) {
+		results = append(results, map[string]any{
+			"type":    resultType,
+			"id":      id,
+			"summary": summary,
+		})
+	}
+
+	normalizedQuery := berpcutils.NormalizeAddress(query)
+
+	switch {
+	case patternNumber.MatchString(query):
+		number, err := strconv.ParseInt(query, 10, 64)
+		if err != nil || number < 1 {
+			break
+		}
+
+		if summary, found := m.searchBlockByHeight(number); found {
+			addResult(searchResultTypeBlock, strconv.FormatInt(number, 10), summary)
+		}
+		if summary, found := m.searchProposal(uint64(number)); found {
+			addResult(searchResultTypeProposal, strconv.FormatInt(number, 10), summary)
+		}
+	case patternTxHash.MatchString(query):
+		if resTx, err := m.GetTransactionByHash(query); err == nil && resTx != nil {
+			txHash := fmt.Sprintf("%v", resTx["hash"])
+			addResult(searchResultTypeTransaction, txHash, map[string]any{
+				"hash":   txHash,
+				"height": resTx["height"],
+			})
+		}
+		if summary, found := m.searchBlockByHash(query); found {
+			addResult(searchResultTypeBlock, fmt.Sprintf("%v", summary["height"]), summary)
+		}
+	case strings.HasPrefix(normalizedQuery, "0x"):
+		if !common.IsHexAddress(normalizedQuery) {
+			break
+		}
+
+		accAddrStr := m.bech32Cfg.ConvertToAccAddressIfHexOtherwiseKeepAsIs(normalizedQuery)
+		addResult(searchResultTypeAccount, accAddrStr, map[string]any{
+			"cosmos": accAddrStr,
+			"evm":    common.HexToAddress(normalizedQuery).Hex(),
+		})
+	case m.bech32Cfg.IsAccountAddr(normalizedQuery):
+		accAddr, err := sdk.AccAddressFromBech32(normalizedQuery)
+		if err != nil {
+			break
+		}
+
+		summary := map[string]any{
+			"cosmos": accAddr.String(),
+		}
+		if m.externalServices.ChainType == berpctypes.ChainTypeEvm {
+			summary["evm"] = common.BytesToAddress(accAddr.Bytes()).Hex()
+		}
+		addResult(searchResultTypeAccount, accAddr.String(), summary)
+	case m.bech32Cfg.IsValAddr(normalizedQuery) || m.bech32Cfg.IsConsAddr(normalizedQuery):
+		valAddr, consAddr, found, err := m.validatorsConsAddrToValAddr.GetValAddrAndConsAddr(normalizedQuery)
+		if err != nil || !found {
+			break
+		}
+
+		summary := map[string]any{
+			"validatorAddress": valAddr,
+			"consensusAddress": consAddr,
+		}
+		if _, moniker, found, err := m.validatorsConsAddrToValAddr.GetValAddrAndMonikerFromConsAddr(consAddr); err == nil && found {
+			summary["moniker"] = moniker
+		}
+		addResult(searchResultTypeValidator, valAddr, summary)
+	}
+
+	if len(results) == 0 && sdk.ValidateDenom(query) == nil {
+		if summary, found := m.searchDenom(query); found {
+			addResult(searchResultTypeDenom, query, summary)
+		}
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"query":   query,
+		"results": results,
+	}, nil
+}
+
+func (m *Backend) searchBlockByHeight(height int64) (summary map[string]any, found bool) {
+	resBlock, err := m.clientCtx.Client.Block(m.ctx, &height)
+	if err != nil || resBlock == nil || resBlock.Block == nil {
+		return nil, false
+	}
+
+	return map[string]any{
+		"height":       resBlock.Block.Height,
+		"hash":         strings.ToUpper(hex.EncodeToString(resBlock.BlockID.Hash)),
+		"timeEpochUTC": resBlock.Block.Time.UTC().Unix(),
+		"txsCount":     len(resBlock.Block.Data.Txs),
+	}, true
+}
+
+func (m *Backend) searchBlockByHash(hashStr string) (summary map[string]any, found bool) {
+	hash, err := hex.DecodeString(berpcutils.NormalizeTransactionHash(hashStr, false)[2:])
+	if err != nil {
+		return nil, false
+	}
+
+	resBlock, err := m.clientCtx.Client.BlockByHash(m.ctx, hash)
+	if err != nil || resBlock == nil || resBlock.Block == nil {
+		return nil, false
+	}
+
+	return map[string]any{
+		"height":       resBlock.Block.Height,
+		"hash":         strings.ToUpper(hex.EncodeToString(resBlock.BlockID.Hash)),
+		"timeEpochUTC": resBlock.Block.Time.UTC().Unix(),
+		"txsCount":     len(resBlock.Block.Data.Txs),
+	}, true
+}
+
+func (m *Backend) searchProposal(proposalId uint64) (summary map[string]any, found bool) {
+	resProposal, err := m.queryClient.GovV1QueryClient.Proposal(m.ctx, &govv1types.QueryProposalRequest{
+		ProposalId: proposalId,
+	})
+	if err != nil || resProposal == nil || resProposal.Proposal == nil {
+		return nil, false
+	}
+
+	return map[string]any{
+		"id":     resProposal.Proposal.Id,
+		"status": resProposal.Proposal.Status.String(),
+	}, true
+}
+
+func (m *Backend) searchDenom(denom string) (summary map[string]any, found bool) {
+	resDenomMetadata, err := m.queryClient.BankQueryClient.DenomMetadata(m.ctx, &banktypes.QueryDenomMetadataRequest{
+		Denom: denom,
+	})
+	if err == nil && resDenomMetadata != nil {
+		return map[string]any{
+			"base":     resDenomMetadata.Metadata.Base,
+			"metadata": berpctypes.NewRpcDenomMetadataFromBankMetadata(resDenomMetadata.Metadata),
+		}, true
+	}
+
+	resSupply, err := m.queryClient.BankQueryClient.SupplyOf(m.ctx, &banktypes.QuerySupplyOfRequest{
+		Denom: denom,
+	})
+	if err != nil || resSupply == nil || resSupply.Amount.IsZero() {
+		return nil, false
+	}
+
+	return map[string]any{
+		"base":        denom,
+		"totalSupply": resSupply.Amount.Amount.String(),
+	}, true
+}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (m *Backend) GetValidatorsUptime(pageNo int) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	signedBlocksWindow, err := m.getSignedBlocksWindow()
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := getDefaultPagination(pageNo)
+	pagination.CountTotal = true
+
+	resSigningInfos, err := m.queryClient.SlashingQueryClient.SigningInfos(m.ctx, &slashingtypes.QuerySigningInfosRequest{
+		Pagination: pagination,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get signing infos").Error())
+	}
+
+	validators := make([]map[string]any, 0, len(resSigningInfos.Info))
+	for _, signingInfo := range resSigningInfos.Info {
+		validatorInfo := signingInfoToMap(signingInfo, signedBlocksWindow)
+		validatorInfo["consensusAddress"] = signingInfo.Address
+
+		valAddr, moniker, found, err := m.validatorsConsAddrToValAddr.GetValAddrAndMonikerFromConsAddr(signingInfo.Address)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get validator address from consensus address").Error())
+		}
+		if found {
+			validatorInfo["validatorAddress"] = valAddr
+			validatorInfo["moniker"] = moniker
+		}
+
+		validators = append(validators, validatorInfo)
+	}
+
+	var totalCount uint64
+	if resSigningInfos.Pagination != nil {
+		totalCount = resSigningInfos.Pagination.Total
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"signedBlocksWindow": signedBlocksWindow,
+		"validators":         validators,
+		"totalCount":         totalCount,
+		"pageNo":             pageNo,
+		"pageSize":           defaultPageSize,
+	}, nil
+}
+
+func (m *Backend) getSignedBlocksWindow() (int64, error) {
+	slashingParams, err := m.queryClient.SlashingQueryClient.Params(m.ctx, &slashingtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, status.Error(codes.Internal, errors.Wrap(err, "failed to get slashing params").Error())
+	}
+	return slashingParams.Params.SignedBlocksWindow, nil
+}
+
+// signingInfoToMap converts the validator signing info, the uptime percentage is computed against the blocks tracked
+// within the signed blocks window. The validator bonded within the window has fewer blocks tracked than the window,
+// the number of blocks tracked is given by the index offset, which is increased every block the validator is bonded.
+func signingInfoToMap(signingInfo slashingtypes.ValidatorSigningInfo, signedBlocksWindow int64) map[string]any {
+	trackedBlocks := signedBlocksWindow
+	if signingInfo.IndexOffset < trackedBlocks {
+		trackedBlocks = signingInfo.IndexOffset
+	}
+
+	uptime := sdk.ZeroDec()
+	if trackedBlocks > 0 {
+		signedBlocks := trackedBlocks - signingInfo.MissedBlocksCounter
+		if signedBlocks < 0 {
+			signedBlocks = 0
+		}
+		uptime = sdk.NewDec(signedBlocks).MulInt64(100).QuoInt64(trackedBlocks)
+	}
+
+	return map[string]any{
+		"startHeight":         signingInfo.StartHeight,
+		"indexOffset":         signingInfo.IndexOffset,
+		"jailedUntilEpochUTC": signingInfo.JailedUntil.UTC().Unix(),
+		"tombstoned":          signingInfo.Tombstoned,
+		"missedBlocksCounter": signingInfo.MissedBlocksCounter,
+		"uptime":              uptime.String(),
+	}
+}
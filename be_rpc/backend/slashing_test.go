@@ -0,0 +1,87 @@
+package backend
+
+import (
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func Test_signingInfoToMap(t *testing.T) {
+	tests := []struct {
+		name               string
+		startHeight        int64
+		indexOffset        int64
+		missedBlocks       int64
+		signedBlocksWindow int64
+		wantUptime         string
+	}{
+		{
+			name:               "signed all blocks",
+			startHeight:        1,
+			indexOffset:        5000,
+			signedBlocksWindow: 1000,
+			wantUptime:         "100.000000000000000000",
+		},
+		{
+			name:               "missed some blocks",
+			startHeight:        1,
+			indexOffset:        5000,
+			missedBlocks:       250,
+			signedBlocksWindow: 1000,
+			wantUptime:         "75.000000000000000000",
+		},
+		{
+			name:               "window is zero",
+			startHeight:        1,
+			indexOffset:        5000,
+			missedBlocks:       10,
+			signedBlocksWindow: 0,
+			wantUptime:         "0.000000000000000000",
+		},
+		{
+			name:               "missed more than the window",
+			startHeight:        1,
+			indexOffset:        5000,
+			missedBlocks:       1500,
+			signedBlocksWindow: 1000,
+			wantUptime:         "0.000000000000000000",
+		},
+		{
+			name:               "start height inside the window, computed against the tracked blocks",
+			startHeight:        4801,
+			indexOffset:        200,
+			missedBlocks:       50,
+			signedBlocksWindow: 1000,
+			wantUptime:         "75.000000000000000000",
+		},
+		{
+			name:               "start height inside the window, signed all tracked blocks",
+			startHeight:        4801,
+			indexOffset:        200,
+			signedBlocksWindow: 1000,
+			wantUptime:         "100.000000000000000000",
+		},
+		{
+			name:               "just bonded, no block tracked",
+			startHeight:        5000,
+			indexOffset:        0,
+			signedBlocksWindow: 1000,
+			wantUptime:         "0.000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := signingInfoToMap(slashingtypes.ValidatorSigningInfo{
+				StartHeight:         tt.startHeight,
+				IndexOffset:         tt.indexOffset,
+				JailedUntil:         time.Unix(0, 0),
+				MissedBlocksCounter: tt.missedBlocks,
+			}, tt.signedBlocksWindow)
+
+			require.Equal(t, tt.wantUptime, got["uptime"])
+			require.Equal(t, tt.startHeight, got["startHeight"])
+			require.Equal(t, tt.missedBlocks, got["missedBlocksCounter"])
+		})
+	}
+}
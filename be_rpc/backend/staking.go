@@ -2,12 +2,14 @@ package backend
 
 import (
 	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	disttypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"sort"
 	"strings"
 )
 
@@ -53,9 +55,25 @@ func (m *Backend) GetStakingInfo(delegatorAddr string) (berpctypes.GenericBacken
 		}
 	}
 
+	resUbd, err := m.queryClient.StakingQueryClient.DelegatorUnbondingDelegations(m.ctx, &stakingtypes.QueryDelegatorUnbondingDelegationsRequest{
+		DelegatorAddr: unsafeDelegatorAddr,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get delegator unbonding delegations").Error())
+	}
+
+	resRed, err := m.queryClient.StakingQueryClient.Redelegations(m.ctx, &stakingtypes.QueryRedelegationsRequest{
+		DelegatorAddr: unsafeDelegatorAddr,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get delegator redelegations").Error())
+	}
+
 	totalRewards := sdk.DecCoins{}
+	rewardsByValidator := make(map[string]map[string]string)
 	for _, reward := range resDist.Rewards {
 		totalRewards = totalRewards.Add(reward.Reward...)
+		rewardsByValidator[reward.ValidatorAddress] = berpcutils.DecCoinsToMap(reward.Reward...)
 	}
 
 	resStakingInfo := make(berpctypes.GenericBackendResponse)
@@ -63,9 +81,44 @@ func (m *Backend) GetStakingInfo(delegatorAddr string) (berpctypes.GenericBacken
 		resStakingInfo[delegation.Delegation.ValidatorAddress] = delegation.Balance.Amount.String()
 	}
 
+	var bondDenom string
+	if len(resUbd.UnbondingResponses) > 0 || len(resRed.RedelegationResponses) > 0 {
+		bondDenom, err = m.getBondDenom()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unbonding := make(map[string][]map[string]any)
+	for _, unbondingDelegation := range resUbd.UnbondingResponses {
+		unbonding[unbondingDelegation.ValidatorAddress] = unbondingDelegationEntriesToMaps(unbondingDelegation.Entries, bondDenom)
+	}
+
+	redelegations := make([]map[string]any, 0, len(resRed.RedelegationResponses))
+	for _, redelegation := range resRed.RedelegationResponses {
+		entries := make([]map[string]any, 0, len(redelegation.Entries))
+		for _, entry := range redelegation.Entries {
+			entries = append(entries, map[string]any{
+				"creationHeight":         entry.RedelegationEntry.CreationHeight,
+				"completionTimeEpochUTC": entry.RedelegationEntry.CompletionTime.UTC().Unix(),
+				"initialBalance":         berpcutils.CoinsToMap(sdk.NewCoin(bondDenom, entry.RedelegationEntry.InitialBalance)),
+				"balance":                berpcutils.CoinsToMap(sdk.NewCoin(bondDenom, entry.Balance)),
+			})
+		}
+
+		redelegations = append(redelegations, map[string]any{
+			"srcValidatorAddress": redelegation.Redelegation.ValidatorSrcAddress,
+			"dstValidatorAddress": redelegation.Redelegation.ValidatorDstAddress,
+			"entries":             entries,
+		})
+	}
+
 	res := berpctypes.GenericBackendResponse{
-		"staking": resStakingInfo,
-		"rewards": totalRewards.String(),
+		"staking":            resStakingInfo,
+		"rewards":            totalRewards.String(),
+		"rewardsByValidator": rewardsByValidator,
+		"unbonding":          unbonding,
+		"redelegations":      redelegations,
 	}
 
 	if !validatorCommission.IsZero() {
@@ -101,3 +154,259 @@ func (m *Backend) GetValidators() (berpctypes.GenericBackendResponse, error) {
 
 	return res, nil
 }
+
+// bondStatuses maps the supported status filter to the staking bond status.
+var bondStatuses = map[string]stakingtypes.BondStatus{
+	"bonded":    stakingtypes.Bonded,
+	"unbonding": stakingtypes.Unbonding,
+	"unbonded":  stakingtypes.Unbonded,
+}
+
+func (m *Backend) GetStakingValidators(statusFilter string, pageNo int) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	var bondStatus stakingtypes.BondStatus
+	statusFilter = strings.ToLower(strings.TrimSpace(statusFilter))
+	if statusFilter != "" && statusFilter != "all" {
+		bs, found := bondStatuses[statusFilter]
+		if !found {
+			return nil, berpctypes.ErrBadRequest
+		}
+		bondStatus = bs
+	}
+
+	// all validators must be loaded to be sorted by voting power
+	allStakingValidators, err := m.stakingValidatorsCache.GetValidators()
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get staking validators").Error())
+	}
+
+	var stakingValidators []stakingtypes.Validator
+	for _, validator := range allStakingValidators {
+		if bondStatus == stakingtypes.Unspecified || validator.Status == bondStatus {
+			stakingValidators = append(stakingValidators, validator)
+		}
+	}
+
+	tmValidators, err := m.tendermintValidatorsCache.GetValidators()
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get validators").Error())
+	}
+
+	var totalVotingPower int64
+	votingPowerByConsAddr := make(map[string]int64)
+	for _, tmValidator := range tmValidators {
+		votingPowerByConsAddr[sdk.ConsAddress(tmValidator.Address).String()] = tmValidator.VotingPower
+		totalVotingPower += tmValidator.VotingPower
+	}
+
+	type validatorWithPower struct {
+		validator   stakingtypes.Validator
+		consAddr    string
+		votingPower int64
+	}
+
+	validators := make([]validatorWithPower, 0, len(stakingValidators))
+	for _, validator := range stakingValidators {
+		var consAddrStr string
+		var votingPower int64
+		if consAddr, success := berpcutils.FromAnyPubKeyToConsensusAddress(validator.ConsensusPubkey, m.clientCtx.Codec); success {
+			consAddrStr = consAddr.String()
+			votingPower = votingPowerByConsAddr[consAddrStr]
+		}
+
+		validators = append(validators, validatorWithPower{
+			validator:   validator,
+			consAddr:    consAddrStr,
+			votingPower: votingPower,
+		})
+	}
+
+	sort.SliceStable(validators, func(i, j int) bool {
+		if validators[i].votingPower != validators[j].votingPower {
+			return validators[i].votingPower > validators[j].votingPower
+		}
+		return validators[i].validator.Tokens.GT(validators[j].validator.Tokens)
+	})
+
+	// cumulative share is computed over the sorted list, so it is consistent across pages
+	cumulativeShares := make([]sdk.Dec, len(validators))
+	votingPowerShares := make([]sdk.Dec, len(validators))
+	cumulativeShare := sdk.ZeroDec()
+	for i, validator := range validators {
+		votingPowerShare := sdk.ZeroDec()
+		if totalVotingPower > 0 {
+			votingPowerShare = sdk.NewDec(validator.votingPower).QuoInt64(totalVotingPower)
+		}
+		cumulativeShare = cumulativeShare.Add(votingPowerShare)
+
+		votingPowerShares[i] = votingPowerShare
+		cumulativeShares[i] = cumulativeShare
+	}
+
+	validatorsInfo := make([]map[string]any, 0)
+	for i := defaultPageSize * (pageNo - 1); i < len(validators) && len(validatorsInfo) < defaultPageSize; i++ {
+		validator := validators[i].validator
+
+		validatorInfo := map[string]any{
+			"operatorAddress": validator.OperatorAddress,
+			"consAddress":     validators[i].consAddr,
+			"moniker":         validator.Description.Moniker,
+			"commission": map[string]string{
+				"rate":          validator.Commission.CommissionRates.Rate.String(),
+				"maxRate":       validator.Commission.CommissionRates.MaxRate.String(),
+				"maxChangeRate": validator.Commission.CommissionRates.MaxChangeRate.String(),
+			},
+			"jailed":           validator.Jailed,
+			"status":           validator.Status.String(),
+			"tokens":           validator.Tokens.String(),
+			"votingPower":      validators[i].votingPower,
+			"votingPowerShare": votingPowerShares[i].String(),
+			"cumulativeShare":  cumulativeShares[i].String(),
+		}
+
+		if valAddr, err := sdk.ValAddressFromBech32(validator.OperatorAddress); err == nil {
+			selfDelegation, err := m.stakingValidatorsCache.GetSelfDelegation(valAddr)
+			if err != nil {
+				return nil, status.Error(codes.Internal, errors.Wrapf(err, "failed to get self-delegation of %s", validator.OperatorAddress).Error())
+			}
+			validatorInfo["selfDelegation"] = selfDelegation
+		}
+
+		validatorsInfo = append(validatorsInfo, validatorInfo)
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"validators":       validatorsInfo,
+		"totalCount":       len(validators),
+		"totalVotingPower": totalVotingPower,
+		"pageNo":           pageNo,
+		"pageSize":         defaultPageSize,
+	}, nil
+}
+
+func (m *Backend) GetValidatorDelegations(valAddr string, pageNo int) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	valAddr, err := m.normalizeValidatorAddress(valAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := getDefaultPagination(pageNo)
+	pagination.CountTotal = true
+
+	resDelegations, err := m.queryClient.StakingQueryClient.ValidatorDelegations(m.ctx, &stakingtypes.QueryValidatorDelegationsRequest{
+		ValidatorAddr: valAddr,
+		Pagination:    pagination,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get validator delegations").Error())
+	}
+
+	delegations := make([]map[string]any, 0, len(resDelegations.DelegationResponses))
+	for _, delegation := range resDelegations.DelegationResponses {
+		delegations = append(delegations, map[string]any{
+			"delegatorAddress": delegation.Delegation.DelegatorAddress,
+			"balance":          berpcutils.CoinsToMap(delegation.Balance),
+		})
+	}
+
+	var totalCount uint64
+	if resDelegations.Pagination != nil {
+		totalCount = resDelegations.Pagination.Total
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"validatorAddress": valAddr,
+		"delegations":      delegations,
+		"totalCount":       totalCount,
+		"pageNo":           pageNo,
+		"pageSize":         defaultPageSize,
+	}, nil
+}
+
+func (m *Backend) GetValidatorUnbondingDelegations(valAddr string, pageNo int) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	valAddr, err := m.normalizeValidatorAddress(valAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	bondDenom, err := m.getBondDenom()
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := getDefaultPagination(pageNo)
+	pagination.CountTotal = true
+
+	resUnbondingDelegations, err := m.queryClient.StakingQueryClient.ValidatorUnbondingDelegations(m.ctx, &stakingtypes.QueryValidatorUnbondingDelegationsRequest{
+		ValidatorAddr: valAddr,
+		Pagination:    pagination,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to get validator unbonding delegations").Error())
+	}
+
+	unbondingDelegations := make([]map[string]any, 0, len(resUnbondingDelegations.UnbondingResponses))
+	for _, unbondingDelegation := range resUnbondingDelegations.UnbondingResponses {
+		unbondingDelegations = append(unbondingDelegations, map[string]any{
+			"delegatorAddress": unbondingDelegation.DelegatorAddress,
+			"entries":          unbondingDelegationEntriesToMaps(unbondingDelegation.Entries, bondDenom),
+		})
+	}
+
+	var totalCount uint64
+	if resUnbondingDelegations.Pagination != nil {
+		totalCount = resUnbondingDelegations.Pagination.Total
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"validatorAddress":     valAddr,
+		"unbondingDelegations": unbondingDelegations,
+		"totalCount":           totalCount,
+		"pageNo":               pageNo,
+		"pageSize":             defaultPageSize,
+	}, nil
+}
+
+// normalizeValidatorAddress returns the normalized validator operator address, or ErrBadAddress if invalid.
+func (m *Backend) normalizeValidatorAddress(valAddr string) (string, error) {
+	valAddr = strings.ToLower(strings.TrimSpace(valAddr))
+	if !m.bech32Cfg.IsValAddr(valAddr) {
+		return "", berpctypes.ErrBadAddress
+	}
+	if _, err := sdk.ValAddressFromBech32(valAddr); err != nil {
+		return "", berpctypes.ErrBadAddress
+	}
+	return valAddr, nil
+}
+
+func (m *Backend) getBondDenom() (string, error) {
+	stakingParams, err := m.queryClient.StakingQueryClient.Params(m.ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return "", status.Error(codes.Internal, errors.Wrap(err, "failed to get staking params").Error())
+	}
+	return stakingParams.Params.BondDenom, nil
+}
+
+func unbondingDelegationEntriesToMaps(entries []stakingtypes.UnbondingDelegationEntry, bondDenom string) []map[string]any {
+	res := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, map[string]any{
+			"creationHeight":         entry.CreationHeight,
+			"completionTimeEpochUTC": entry.CompletionTime.UTC().Unix(),
+			"initialBalance":         berpcutils.CoinsToMap(sdk.NewCoin(bondDenom, entry.InitialBalance)),
+			"balance":                berpcutils.CoinsToMap(sdk.NewCoin(bondDenom, entry.Balance)),
+		})
+	}
+	return res
+}
@@ -8,7 +8,9 @@ import (
 	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
 	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
@@ -22,12 +24,18 @@ import (
 	ibctypes "github.com/cosmos/ibc-go/v6/modules/core/02-client/types"
 	connectiontypes "github.com/cosmos/ibc-go/v6/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v6/modules/core/04-channel/types"
+	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	coretypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 var patternTxHash = regexp.MustCompile(`^(0[xX])?[\da-fA-F]{64}$`)
@@ -60,85 +68,106 @@ func (m *Backend) GetTransactionsInBlockRange(fromHeightIncluded, toHeightInclud
 	missingBlocks := make(berpctypes.Tracker[int64])
 	errorBlocks := make(berpctypes.Tracker[int64])
 
-	txsByBlock := make(map[int64]map[string]any)
-	for height := fromHeightIncluded; height <= toHeightIncluded; height++ {
+	type blockFetchResult struct {
+		txsInfo      []map[string]any
+		timeEpochUTC int64
+		missing      bool
+		error        bool
+	}
+
+	fetchResults := make([]blockFetchResult, toHeightIncluded-fromHeightIncluded+1)
+
+	fetchBlock := func(height int64) (result blockFetchResult) {
 		resBlock, err := m.queryClient.GetBlockWithTxs(m.ctx, &tx.GetBlockWithTxsRequest{
 			Height: height,
 		})
 		if err != nil {
 			m.GetLogger().Error("failed to get block", "height", height, "error", err)
-			missingBlocks.Add(height)
-			continue
+			result.missing = true
+			return
 		}
 		if resBlock == nil {
 			m.GetLogger().Error("block not found", "height", height)
-			missingBlocks.Add(height)
-			continue
+			result.missing = true
+			return
+		}
+
+		// the block results are optional, the transactions are still returned without, as the events only enrich the info
+		var resBlockResults *coretypes.ResultBlockResults
+		if len(resBlock.Block.Data.Txs) > 0 {
+			resBlockResults, err = m.clientCtx.Client.BlockResults(m.ctx, &height)
+			if err != nil {
+				m.GetLogger().Error("failed to get block results", "height", height, "error", err)
+				resBlockResults = nil
+			}
 		}
 
-		var txsInfo []map[string]any
 		for txIdx := 0; txIdx < len(resBlock.Block.Data.Txs); txIdx++ {
 			tx := resBlock.Txs[txIdx]
 			tmTx := tmtypes.Tx(resBlock.Block.Data.Txs[txIdx])
-			txHash := strings.ToUpper(hex.EncodeToString(tmTx.Hash()))
-			txType := "cosmos"
 
-			var optionalTxResult *coretypes.ResultTx
-			if berpcutils.IsEvmTx(tx) {
-				var errTxResult error
-				optionalTxResult, errTxResult = m.clientCtx.Client.Tx(m.ctx, tmTx.Hash(), false)
+			var txEvents []abci.Event
+			if resBlockResults != nil {
+				if txIdx < len(resBlockResults.TxsResults) {
+					txEvents = resBlockResults.TxsResults[txIdx].Events
+				}
+			} else if berpcutils.IsEvmTx(tx) {
+				// fallback to query the tx result, to detect the EVM transaction hash
+				resTx, errTxResult := m.clientCtx.Client.Tx(m.ctx, tmTx.Hash(), false)
 				if errTxResult != nil {
 					m.GetLogger().Error("failed to query tx", "error", errTxResult)
-				} else if optionalTxResult == nil {
-					// ignore
-				} else if evmTxHash := berpcutils.GetEvmTransactionHashFromEvent(optionalTxResult.TxResult.Events); evmTxHash != nil {
-					txHash = berpcutils.NormalizeTransactionHash(evmTxHash.String(), false)
-					txType = "evm"
+				} else if resTx != nil {
+					txEvents = resTx.TxResult.Events
 				}
 			}
 
-			var involvers berpctypes.MessageInvolversResult
-			var messagesType []string
-
-			for _, msg := range tx.Body.Messages {
-				messagesType = append(messagesType, msg.TypeUrl)
+			txInfo, err := m.getTransactionSummary(tx, tmTx, txEvents, true)
+			if err != nil {
+				m.GetLogger().Error("failed to unpack message", "error", err)
+				result.error = true
+				return
+			}
 
-				var cosmosMsg sdk.Msg
-				err := m.clientCtx.Codec.UnpackAny(msg, &cosmosMsg)
-				if err != nil {
-					errorBlocks.Add(height)
-					m.GetLogger().Error("failed to unpack message", "error", err)
-					break
-				}
+			result.txsInfo = append(result.txsInfo, txInfo)
+		}
 
-				var messageInvolversExtractor berpctypes.MessageInvolversExtractor
-				if extractor, found := m.messageInvolversExtractors[berpcutils.ProtoMessageName(cosmosMsg)]; found {
-					messageInvolversExtractor = extractor
-				} else {
-					messageInvolversExtractor = m.defaultMessageInvolversExtractor
-				}
+		result.timeEpochUTC = resBlock.Block.Header.Time.UTC().Unix()
+		return
+	}
 
-				resInvolvers, err := messageInvolversExtractor(cosmosMsg, tx, tmTx, m.clientCtx)
-				if err == nil {
-					involvers = resInvolvers.Finalize()
-				}
+	heightsToFetch := make(chan int64)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < tmmath.MinInt(m.getBlockFetchConcurrency(), len(fetchResults)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heightsToFetch {
+				fetchResults[height-fromHeightIncluded] = fetchBlock(height)
 			}
+		}()
+	}
+	for height := fromHeightIncluded; height <= toHeightIncluded; height++ {
+		heightsToFetch <- height
+	}
+	close(heightsToFetch)
+	wg.Wait()
 
-			txsInfo = append(txsInfo, map[string]any{
-				"hash":         txHash,
-				"type":         txType,
-				"involvers":    involvers,
-				"messagesType": messagesType,
-			})
-		}
+	txsByBlock := make(map[int64]map[string]any)
+	for i, fetchResult := range fetchResults {
+		height := fromHeightIncluded + int64(i)
 
-		if errorBlocks.Has(height) {
+		if fetchResult.missing {
+			missingBlocks.Add(height)
+			continue
+		}
+		if fetchResult.error {
+			errorBlocks.Add(height)
 			continue
 		}
 
 		txsByBlock[height] = map[string]any{
-			"timeEpochUTC": resBlock.Block.Header.Time.UTC().Unix(),
-			"txs":          txsInfo,
+			"timeEpochUTC": fetchResult.timeEpochUTC,
+			"txs":          fetchResult.txsInfo,
 		}
 	}
 
@@ -167,9 +196,21 @@ func (m *Backend) GetTransactionByHash(hashStr string) (berpctypes.GenericBacken
 	}
 
 	hash := berpcutils.NormalizeTransactionHash(hashStr, true)
+	cosmosTxHash := hash[2:]
+
+	evmTxResult, err := m.getEvmTxResultFromIndexer(hash)
+	if err != nil {
+		return nil, err
+	}
+	if evmTxResult != nil {
+		cosmosTxHash, err = m.getCosmosTxHashFromEvmTxResult(evmTxResult)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := m.queryClient.GetTx(m.ctx, &tx.GetTxRequest{
-		Hash: hash[2:],
+		Hash: cosmosTxHash,
 	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -178,10 +219,22 @@ func (m *Backend) GetTransactionByHash(hashStr string) (berpctypes.GenericBacken
 		return nil, status.Error(codes.NotFound, "transaction not found")
 	}
 
-	tx := res.Tx
-	txRes := res.TxResponse
-	txEvents := berpctypes.ConvertTxEvent(txRes.Events).RemoveUnnecessaryEvmTxEvents()
+	response, err := m.buildTransactionResponse(res.Tx, res.TxResponse)
+	if err != nil {
+		return nil, err
+	}
 
+	if evmTxResult != nil {
+		// the transaction might contain multiple EVM transactions, use the one queried
+		response["evmTx"] = evmTxResultToMap(hash, evmTxResult)
+	}
+
+	return response, nil
+}
+
+// parseMessages parses the messages of the transaction using the registered message parsers,
+// fallback to the default message parser if no custom parser registered for the message type.
+func (m *Backend) parseMessages(tx *tx.Tx, txRes *sdk.TxResponse) ([]map[string]any, error) {
 	msgsInfo := make([]map[string]any, 0)
 	for msgIdx, msg := range tx.Body.Messages {
 		var cosmosMsg sdk.Msg
@@ -223,6 +276,144 @@ func (m *Backend) GetTransactionByHash(hashStr string) (berpctypes.GenericBacken
 		}
 	}
 
+	return msgsInfo, nil
+}
+
+// getAuthInfo returns the fee, tip, timeout height and signer information of the transaction.
+func (m *Backend) getAuthInfo(tx *tx.Tx) berpctypes.GenericBackendResponse {
+	authInfo := berpctypes.GenericBackendResponse{
+		"timeoutHeight": tx.Body.TimeoutHeight,
+	}
+
+	if tx.AuthInfo == nil {
+		return authInfo
+	}
+
+	if tx.AuthInfo.Fee != nil {
+		feeInfo := feeToMap(tx.AuthInfo.Fee)
+		if feePayer := m.getFeePayer(tx); len(feePayer) > 0 {
+			feeInfo["payer"] = feePayer
+		}
+		if feeGranter := tx.AuthInfo.Fee.Granter; len(feeGranter) > 0 {
+			feeInfo["granter"] = feeGranter
+		}
+		authInfo["fee"] = feeInfo
+	}
+
+	if tx.AuthInfo.Tip != nil {
+		authInfo["tip"] = tipToMap(tx.AuthInfo.Tip)
+	}
+
+	signerInfos := make([]map[string]any, 0)
+	for _, signerInfo := range tx.AuthInfo.SignerInfos {
+		signerInfoMap := map[string]any{
+			"sequence": signerInfo.Sequence,
+		}
+
+		if signerInfo.PublicKey != nil {
+			publicKey, err := berpcutils.FromAnyToJsonMap(signerInfo.PublicKey, m.clientCtx.Codec)
+			if err != nil {
+				signerInfoMap["publicKeyError"] = err.Error()
+			} else {
+				signerInfoMap["publicKey"] = publicKey
+			}
+		}
+
+		if signerInfo.ModeInfo != nil {
+			signerInfoMap["modeInfo"] = modeInfoToMap(signerInfo.ModeInfo)
+		}
+
+		signerInfos = append(signerInfos, signerInfoMap)
+	}
+	authInfo["signerInfos"] = signerInfos
+
+	return authInfo
+}
+
+// getFeePayer returns the fee payer of the transaction, which is the first signer if not specified.
+// Unlike tx.FeePayer(), it does not panic on malformed transaction, empty is returned if not able to determine.
+func (m *Backend) getFeePayer(tx *tx.Tx) string {
+	if payer := tx.AuthInfo.Fee.Payer; len(payer) > 0 {
+		return payer
+	}
+
+	if len(tx.Body.Messages) == 0 {
+		return ""
+	}
+
+	var cosmosMsg sdk.Msg
+	if err := m.clientCtx.Codec.UnpackAny(tx.Body.Messages[0], &cosmosMsg); err != nil {
+		return ""
+	}
+
+	signers, err := getMsgSigners(cosmosMsg)
+	if err != nil {
+		m.GetLogger().Debug("failed to get signers", "msg-type", berpcutils.ProtoMessageName(cosmosMsg), "error", err)
+		return ""
+	}
+	if len(signers) == 0 {
+		return ""
+	}
+
+	return signers[0].String()
+}
+
+// getMsgSigners returns the signers of the message.
+// The implementations of GetSigners panic on malformed message, like invalid bech32 address or unsigned MsgEthereumTx,
+// so the panic is recovered and returned as error.
+func getMsgSigners(msg sdk.Msg) (signers []sdk.AccAddress, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to get signers: %v", r)
+		}
+	}()
+
+	return msg.GetSigners(), nil
+}
+
+func feeToMap(fee *tx.Fee) map[string]any {
+	return map[string]any{
+		"gasLimit": fee.GasLimit,
+		"amount":   berpcutils.CoinsToMap(fee.Amount...),
+	}
+}
+
+func tipToMap(tip *tx.Tip) map[string]any {
+	return map[string]any{
+		"tipper": tip.Tipper,
+		"amount": berpcutils.CoinsToMap(tip.Amount...),
+	}
+}
+
+func modeInfoToMap(modeInfo *tx.ModeInfo) map[string]any {
+	if single := modeInfo.GetSingle(); single != nil {
+		return map[string]any{
+			"single": single.Mode.String(),
+		}
+	}
+
+	if multi := modeInfo.GetMulti(); multi != nil {
+		modeInfos := make([]map[string]any, 0)
+		for _, childModeInfo := range multi.ModeInfos {
+			modeInfos = append(modeInfos, modeInfoToMap(childModeInfo))
+		}
+		return map[string]any{
+			"multi": modeInfos,
+		}
+	}
+
+	return map[string]any{}
+}
+
+// buildTransactionResponse builds the transaction details response from the transaction and its execution result.
+func (m *Backend) buildTransactionResponse(tx *tx.Tx, txRes *sdk.TxResponse) (berpctypes.GenericBackendResponse, error) {
+	txEvents := berpctypes.ConvertTxEvent(txRes.Events).RemoveUnnecessaryEvmTxEvents()
+
+	msgsInfo, err := m.parseMessages(tx, txRes)
+	if err != nil {
+		return nil, err
+	}
+
 	response := berpctypes.GenericBackendResponse{
 		"height": txRes.Height,
 		"hash":   txRes.TxHash,
@@ -242,9 +433,519 @@ func (m *Backend) GetTransactionByHash(hashStr string) (berpctypes.GenericBacken
 		response["memo"] = tx.Body.Memo
 	}
 
+	if txTime, err := time.Parse(time.RFC3339, txRes.Timestamp); err == nil {
+		response["timeEpochUTC"] = txTime.UTC().Unix()
+	}
+
+	response["authInfo"] = m.getAuthInfo(tx)
+
+	if berpcutils.IsEvmTx(tx) {
+		evmTx, err := m.getEvmTxInfo(txRes.Events)
+		if err != nil {
+			return nil, err
+		}
+		if evmTx != nil {
+			response["evmTx"] = evmTx
+		}
+
+		if evmTransfers := getEvmTransfersInfo(txRes.Events); len(evmTransfers) > 0 {
+			response["evmTransfers"] = evmTransfers
+		}
+	}
+
 	return response, nil
 }
 
+// getEvmTxInfo returns the EVM transaction info, looked up by the first EVM transaction hash in the events,
+// using the external EVM tx indexer. Returns nil if the indexer is not provided or the transaction could not be found.
+func (m *Backend) getEvmTxInfo(events []abci.Event) (berpctypes.GenericBackendResponse, error) {
+	evmTxHash := berpcutils.GetEvmTransactionHashFromEvent(events)
+	if evmTxHash == nil {
+		return nil, nil
+	}
+
+	evmTxResult, err := m.getEvmTxResultFromIndexer(evmTxHash.String())
+	if err != nil {
+		return nil, err
+	}
+	if evmTxResult == nil {
+		return nil, nil
+	}
+
+	return evmTxResultToMap(evmTxHash.String(), evmTxResult), nil
+}
+
+func evmTxResultToMap(hash string, evmTxResult berpctypes.TxResultForExternal) berpctypes.GenericBackendResponse {
+	return berpctypes.GenericBackendResponse{
+		"hash":              berpcutils.NormalizeTransactionHash(hash, false),
+		"ethTxIndex":        evmTxResult.GetEthTxIndex(),
+		"msgIndex":          evmTxResult.GetMsgIndex(),
+		"gasUsed":           evmTxResult.GetGasUsed(),
+		"cumulativeGasUsed": evmTxResult.GetCumulativeGasUsed(),
+		"failed":            evmTxResult.GetFailed(),
+	}
+}
+
+// getEvmTransfersInfo decodes the token transfers from the EVM logs, which were removed from the events.
+func getEvmTransfersInfo(events []abci.Event) []berpctypes.GenericBackendResponse {
+	transfers := berpcutils.DecodeEvmTokenTransfers(berpcutils.ParseEvmLogsFromTxEvents(events))
+
+	evmTransfers := make([]berpctypes.GenericBackendResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		contract := strings.ToLower(transfer.Contract.String())
+		from := strings.ToLower(transfer.From.String())
+		to := strings.ToLower(transfer.To.String())
+
+		res := berpctypes.GenericBackendResponse{
+			"standard": transfer.Standard,
+			"contract": contract,
+			"from":     from,
+			"to":       to,
+		}
+
+		rb := berpctypes.NewFriendlyResponseContentBuilder()
+		switch {
+		case transfer.From == (common.Address{}):
+			rb.WriteAddress(to).WriteText(" receives minted ")
+		case transfer.To == (common.Address{}):
+			rb.WriteAddress(from).WriteText(" burns ")
+		default:
+			rb.WriteAddress(from).WriteText(" transfers ")
+		}
+
+		if transfer.Amount != nil {
+			res["amount"] = transfer.Amount.String()
+			rb.WriteText(transfer.Amount.String()).WriteText(" ")
+		}
+		if transfer.TokenId != nil {
+			res["tokenId"] = transfer.TokenId.String()
+			rb.WriteText("token id ").WriteText(transfer.TokenId.String()).WriteText(" of ")
+		}
+		if tokenWriter, ok := rb.(berpctypes.FriendlyResponseContentTokenWriterI); ok {
+			tokenWriter.WriteToken(contract)
+		} else {
+			rb.WriteAddress(contract)
+		}
+
+		if transfer.From != (common.Address{}) && transfer.To != (common.Address{}) {
+			rb.WriteText(" to ").WriteAddress(to)
+		}
+
+		rb.BuildIntoResponse(res)
+		evmTransfers = append(evmTransfers, res)
+	}
+
+	return evmTransfers
+}
+
+// getEvmTxResultFromIndexer looks up the EVM transaction hash using the external EVM tx indexer.
+// Returns nil if the indexer is not provided or the transaction could not be found.
+func (m *Backend) getEvmTxResultFromIndexer(hash string) (berpctypes.TxResultForExternal, error) {
+	if m.externalServices.EvmTxIndexer == nil {
+		return nil, nil
+	}
+
+	evmTxResult, err := m.externalServices.EvmTxIndexer.GetByTxHashForExternal(common.HexToHash(hash))
+	if err != nil {
+		// the hash might be a Cosmos transaction hash, so just ignore
+		m.GetLogger().Debug("failed to lookup EVM tx hash from indexer", "hash", hash, "error", err)
+		return nil, nil
+	}
+
+	return evmTxResult, nil
+}
+
+// getCosmosTxHashFromEvmTxResult returns the Cosmos transaction hash which contains the indexed EVM transaction.
+func (m *Backend) getCosmosTxHashFromEvmTxResult(evmTxResult berpctypes.TxResultForExternal) (string, error) {
+	height := evmTxResult.GetHeight()
+	resBlock, err := m.clientCtx.Client.Block(m.ctx, &height)
+	if err != nil {
+		return "", status.Error(codes.Internal, errors.Wrap(err, "failed to get block").Error())
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return "", status.Error(codes.NotFound, "block not found")
+	}
+
+	txIndex := int(evmTxResult.GetTxIndex())
+	if txIndex >= len(resBlock.Block.Data.Txs) {
+		return "", status.Error(codes.NotFound, "transaction not found")
+	}
+
+	return strings.ToUpper(hex.EncodeToString(resBlock.Block.Data.Txs[txIndex].Hash())), nil
+}
+
+// getTransactionSummary builds the lightweight transaction info used by the transaction listing endpoints.
+// The transaction events are optional, when provided, they are used to detect the EVM transaction hash.
+// Committed is false for the transactions not included in any block, like decoded or mempool transactions,
+// the tx result of those can not be queried to extract involvers.
+// Involvers are merged across all messages, the role of each address is provided per message.
+func (m *Backend) getTransactionSummary(tx *tx.Tx, tmTx tmtypes.Tx, txEvents []abci.Event, committed bool) (map[string]any, error) {
+	txHash := strings.ToUpper(hex.EncodeToString(tmTx.Hash()))
+	txType := "cosmos"
+
+	if berpcutils.IsEvmTx(tx) {
+		if evmTxHash := berpcutils.GetEvmTransactionHashFromEvent(txEvents); evmTxHash != nil {
+			txHash = berpcutils.NormalizeTransactionHash(evmTxHash.String(), false)
+			txType = "evm"
+		}
+	}
+
+	involvers := make(berpctypes.MessageInvolversResult)
+	var messagesType []string
+	var messagesInvolvers []berpctypes.MessageInvolverRoles
+
+	// the involvers of each message are extracted from the events emitted by the message
+	msgsEvents, splitEventsOk := berpcutils.SplitTxEventsByMessage(txEvents, len(tx.Body.Messages))
+
+	for msgIdx, msg := range tx.Body.Messages {
+		messagesType = append(messagesType, msg.TypeUrl)
+
+		var cosmosMsg sdk.Msg
+		err := m.clientCtx.Codec.UnpackAny(msg, &cosmosMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		var messageInvolversExtractor messageInvolversWithRolesExtractor
+		if extractor, found := m.messageInvolversExtractors[berpcutils.ProtoMessageName(cosmosMsg)]; found {
+			messageInvolversExtractor = withoutRoles(extractor)
+		} else if strings.HasSuffix(msg.TypeUrl, ".MsgEthereumTx") {
+			msgEvents := txEvents
+			if splitEventsOk {
+				msgEvents = msgsEvents[msgIdx]
+			}
+			messageInvolversExtractor = m.newEvmMessageInvolversExtractor(msgEvents)
+		} else {
+			messageInvolversExtractor = m.newDefaultMessageInvolversExtractor(committed)
+		}
+
+		resInvolvers, err := messageInvolversExtractor(cosmosMsg, tx, tmTx, m.clientCtx)
+		if err != nil {
+			messagesInvolvers = append(messagesInvolvers, berpctypes.MessageInvolverRoles{})
+			continue
+		}
+
+		involvers = involvers.Merge(resInvolvers.Involvers)
+		messagesInvolvers = append(messagesInvolvers, resInvolvers.Roles.Finalize())
+	}
+
+	return map[string]any{
+		"hash":              txHash,
+		"type":              txType,
+		"involvers":         involvers.Finalize(),
+		"messagesType":      messagesType,
+		"messagesInvolvers": messagesInvolvers,
+	}, nil
+}
+
+func (m *Backend) GetTransactionsByAccount(accountAddressStr string, pageNo int) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	accountAddressStr = berpcutils.NormalizeAddress(accountAddressStr)
+	if strings.HasPrefix(accountAddressStr, "0x") && !common.IsHexAddress(accountAddressStr) {
+		return nil, berpctypes.ErrBadAddress
+	}
+
+	accAddrStr := m.bech32Cfg.FromAnyToBech32AccountAddrUnsafe(accountAddressStr)
+	if !m.bech32Cfg.IsAccountAddr(accAddrStr) {
+		return nil, berpctypes.ErrBadAddress
+	}
+	if _, err := sdk.AccAddressFromBech32(accAddrStr); err != nil {
+		return nil, berpctypes.ErrBadAddress
+	}
+
+	var txsInfo []map[string]any
+	var totalCount int
+	if m.isIndexerCaughtUp() {
+		var err error
+		txsInfo, totalCount, err = m.getTransactionsByAccountFromIndexer(accAddrStr, pageNo)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// indexer is disabled or still backfilling, fallback to tx search
+		resultTxs, total, err := m.searchTransactionsByAccount(accAddrStr, pageNo)
+		if err != nil {
+			return nil, err
+		}
+
+		txsInfo, err = m.getTransactionsSummaryFromTxSearchResult(resultTxs)
+		if err != nil {
+			return nil, err
+		}
+		totalCount = total
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"address":    accAddrStr,
+		"txs":        txsInfo,
+		"totalCount": totalCount,
+		"pageNo":     pageNo,
+		"pageSize":   defaultPageSize,
+	}, nil
+}
+
+// searchTransactionsByAccount returns the page of transactions involving the account, using Tendermint tx search,
+// and the total number of transactions involving the account.
+//
+// Tendermint tx search does not support OR condition, so the sender-side and recipient-side search results,
+// both ordered by height & tx index descending, are merged using cursors, and the transactions found by both are
+// returned once. The total is the sum of both searches minus the number of transactions matching both.
+func (m *Backend) searchTransactionsByAccount(accAddrStr string, pageNo int) ([]*coretypes.ResultTx, int, error) {
+	senderQuery := fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, accAddrStr)
+	recipientQuery := fmt.Sprintf("%s.%s='%s'", banktypes.EventTypeTransfer, banktypes.AttributeKeyRecipient, accAddrStr)
+
+	senderCursor := m.newTxSearchCursor(senderQuery)
+	recipientCursor := m.newTxSearchCursor(recipientQuery)
+
+	// the first page of each search provides the total count
+	for _, cursor := range []*txSearchCursor{senderCursor, recipientCursor} {
+		if _, err := cursor.peek(); err != nil {
+			return nil, 0, status.Error(codes.Internal, errors.Wrap(err, "failed to search transactions").Error())
+		}
+	}
+
+	countBoth, err := m.countTxSearch(fmt.Sprintf("%s AND %s", senderQuery, recipientQuery))
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, errors.Wrap(err, "failed to search transactions").Error())
+	}
+
+	totalCount := senderCursor.totalCount + recipientCursor.totalCount - countBoth
+	if totalCount < 0 {
+		totalCount = 0
+	}
+
+	skip := defaultPageSize * (pageNo - 1)
+	if skip >= totalCount {
+		return nil, totalCount, nil
+	}
+
+	resultTxs := make([]*coretypes.ResultTx, 0, defaultPageSize)
+	for len(resultTxs) < defaultPageSize {
+		resTx, err := nextOfMergedTxSearchCursors(senderCursor, recipientCursor)
+		if err != nil {
+			return nil, 0, status.Error(codes.Internal, errors.Wrap(err, "failed to search transactions").Error())
+		}
+		if resTx == nil {
+			break
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		resultTxs = append(resultTxs, resTx)
+	}
+
+	return resultTxs, totalCount, nil
+}
+
+// countTxSearch returns the number of transactions matching the Tendermint tx search query.
+func (m *Backend) countTxSearch(query string) (int, error) {
+	page := 1
+	perPage := 1
+
+	resSearch, err := m.clientCtx.Client.TxSearch(m.ctx, query, false, &page, &perPage, "desc")
+	if err != nil {
+		return 0, err
+	}
+
+	return resSearch.TotalCount, nil
+}
+
+// txSearchCursorPageSize is the number of transactions fetched per Tendermint tx search call by txSearchCursor,
+// which is the maximum page size accepted by Tendermint.
+const txSearchCursorPageSize = 100
+
+// txSearchCursor iterates the Tendermint tx search result ordered by height & tx index descending,
+// the pages are fetched on demand.
+type txSearchCursor struct {
+	m     *Backend
+	query string
+
+	buffered   []*coretypes.ResultTx
+	nextPage   int
+	fetched    int
+	totalCount int
+	exhausted  bool
+	last       *coretypes.ResultTx
+}
+
+func (m *Backend) newTxSearchCursor(query string) *txSearchCursor {
+	return &txSearchCursor{
+		m:        m,
+		query:    query,
+		nextPage: 1,
+	}
+}
+
+// peek returns the current transaction of the cursor, or nil if no more transaction.
+func (c *txSearchCursor) peek() (*coretypes.ResultTx, error) {
+	for {
+		for len(c.buffered) > 0 {
+			// new transactions might shift the pages between fetches, skip the ones already returned
+			if c.last != nil && !isTxSearchResultBefore(c.last, c.buffered[0]) {
+				c.buffered = c.buffered[1:]
+				continue
+			}
+			return c.buffered[0], nil
+		}
+
+		if c.exhausted {
+			return nil, nil
+		}
+
+		page := c.nextPage
+		perPage := txSearchCursorPageSize
+
+		resSearch, err := c.m.clientCtx.Client.TxSearch(c.m.ctx, c.query, false, &page, &perPage, "desc")
+		if err != nil {
+			return nil, err
+		}
+
+		c.buffered = resSearch.Txs
+		c.fetched += len(resSearch.Txs)
+		c.totalCount = resSearch.TotalCount
+		c.nextPage++
+		// the next page is only requested when within the range known from the total count
+		c.exhausted = len(resSearch.Txs) == 0 || c.fetched >= c.totalCount
+	}
+}
+
+// pop moves the cursor to the next transaction.
+func (c *txSearchCursor) pop() {
+	if len(c.buffered) > 0 {
+		c.last = c.buffered[0]
+		c.buffered = c.buffered[1:]
+	}
+}
+
+// isTxSearchResultBefore returns true if the transaction comes before the other one, ordered by height & tx index descending.
+func isTxSearchResultBefore(resTx, other *coretypes.ResultTx) bool {
+	if resTx.Height != other.Height {
+		return resTx.Height > other.Height
+	}
+	return resTx.Index > other.Index
+}
+
+// nextOfMergedTxSearchCursors returns the next transaction, ordered by height & tx index descending,
+// from the two cursors, the transaction found by both cursors is returned once.
+// Returns nil if both cursors have no more transaction.
+func nextOfMergedTxSearchCursors(cursor1, cursor2 *txSearchCursor) (*coretypes.ResultTx, error) {
+	resTx1, err := cursor1.peek()
+	if err != nil {
+		return nil, err
+	}
+	resTx2, err := cursor2.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resTx1 == nil && resTx2 == nil:
+		return nil, nil
+	case resTx2 == nil:
+		cursor1.pop()
+		return resTx1, nil
+	case resTx1 == nil:
+		cursor2.pop()
+		return resTx2, nil
+	case resTx1.Height == resTx2.Height && resTx1.Index == resTx2.Index:
+		cursor1.pop()
+		cursor2.pop()
+		return resTx1, nil
+	case isTxSearchResultBefore(resTx1, resTx2):
+		cursor1.pop()
+		return resTx1, nil
+	default:
+		cursor2.pop()
+		return resTx2, nil
+	}
+}
+
+func (m *Backend) SearchTransactions(query string, pageNo int, orderBy string) (berpctypes.GenericBackendResponse, error) {
+	if pageNo < 1 {
+		return nil, berpctypes.ErrBadPageNo
+	}
+
+	query = strings.TrimSpace(query)
+	if len(query) == 0 {
+		return nil, berpctypes.ErrBadRequest
+	}
+	if _, err := tmquery.New(query); err != nil {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	orderBy = strings.ToLower(strings.TrimSpace(orderBy))
+	if len(orderBy) == 0 {
+		orderBy = "desc"
+	}
+	if orderBy != "asc" && orderBy != "desc" {
+		return nil, berpctypes.ErrBadRequest
+	}
+
+	page := pageNo
+	perPage := defaultPageSize
+
+	var resultTxs []*coretypes.ResultTx
+	var totalCount int
+
+	resSearch, err := m.clientCtx.Client.TxSearch(m.ctx, query, false, &page, &perPage, orderBy)
+	if err != nil {
+		if !isTxSearchPageOutOfRange(err) {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to search transactions").Error())
+		}
+
+		// page beyond the last page, no transaction to return but the total count is still reported
+		firstPage := 1
+		onePerPage := 1
+		resSearch, err = m.clientCtx.Client.TxSearch(m.ctx, query, false, &firstPage, &onePerPage, orderBy)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to count transactions").Error())
+		}
+		totalCount = resSearch.TotalCount
+	} else {
+		resultTxs = resSearch.Txs
+		totalCount = resSearch.TotalCount
+	}
+
+	txsInfo, err := m.getTransactionsSummaryFromTxSearchResult(resultTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	return berpctypes.GenericBackendResponse{
+		"txs":        txsInfo,
+		"totalCount": totalCount,
+		"pageNo":     pageNo,
+		"pageSize":   defaultPageSize,
+	}, nil
+}
+
+// getTransactionsSummaryFromTxSearchResult builds the lightweight transaction info, with height, from the tx search result.
+func (m *Backend) getTransactionsSummaryFromTxSearchResult(resultTxs []*coretypes.ResultTx) ([]map[string]any, error) {
+	txsInfo := make([]map[string]any, 0)
+	for _, resTx := range resultTxs {
+		tx, err := m.decodeTx(resTx.Tx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to decode transaction").Error())
+		}
+
+		txInfo, err := m.getTransactionSummary(tx, resTx.Tx, resTx.TxResult.Events, true)
+		if err != nil {
+			return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to unpack message").Error())
+		}
+		txInfo["height"] = resTx.Height
+
+		txsInfo = append(txsInfo, txInfo)
+	}
+
+	return txsInfo, nil
+}
+
 func (m *Backend) defaultMessageParser(msg sdk.Msg, msgIdx uint, tx *tx.Tx, txResponse *sdk.TxResponse) (res berpctypes.GenericBackendResponse, err error) {
 	switch msg := msg.(type) {
 	case *banktypes.MsgSend:
@@ -1193,144 +1894,181 @@ func (m *Backend) addIbcPacketInfoIntoResponse(packet channeltypes.Packet, res b
 	}
 }
 
-func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (res berpctypes.MessageInvolversResult, err error) {
-	res = make(berpctypes.MessageInvolversResult)
+// messageInvolversWithRolesExtractor is the built-in involvers extractor, which provides the roles of the involvers as well.
+type messageInvolversWithRolesExtractor func(msg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (berpctypes.MessageInvolversWithRoles, error)
+
+// withoutRoles adapts the registered involvers extractor, which does not provide roles of the involvers.
+func withoutRoles(extractor berpctypes.MessageInvolversExtractor) messageInvolversWithRolesExtractor {
+	return func(msg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (berpctypes.MessageInvolversWithRoles, error) {
+		res := berpctypes.NewMessageInvolversWithRoles()
+
+		involvers, err := extractor(msg, tx, tmTx, clientCtx)
+		if err != nil {
+			return res, err
+		}
+
+		res.Involvers = res.Involvers.Merge(involvers)
+		return res, nil
+	}
+}
+
+// newDefaultMessageInvolversExtractor returns the built-in involvers extractor for the messages of the Cosmos SDK modules.
+// The addresses within the tx result are extracted for the other messages,
+// only the signers are extracted if the transaction was not committed.
+func (m *Backend) newDefaultMessageInvolversExtractor(committed bool) messageInvolversWithRolesExtractor {
+	return func(msg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context) (berpctypes.MessageInvolversWithRoles, error) {
+		return m.defaultMessageInvolversExtractor(msg, tx, tmTx, clientCtx, committed)
+	}
+}
+
+func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx tmtypes.Tx, clientCtx client.Context, committed bool) (res berpctypes.MessageInvolversWithRoles, err error) {
+	res = berpctypes.NewMessageInvolversWithRoles()
 
 	switch msg := msg.(type) {
 	case *banktypes.MsgSend:
-		res.Add(berpctypes.MessageInvolvers, msg.FromAddress, msg.ToAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.FromAddress)
+		res.AddWithRole(berpctypes.InvolverRoleRecipient, msg.ToAddress)
 		return
 	case *banktypes.MsgMultiSend:
 		for _, input := range msg.Inputs {
-			res.Add(berpctypes.MessageInvolvers, input.Address)
+			res.AddWithRole(berpctypes.InvolverRoleSender, input.Address)
 		}
 		for _, output := range msg.Outputs {
-			res.Add(berpctypes.MessageInvolvers, output.Address)
+			res.AddWithRole(berpctypes.InvolverRoleRecipient, output.Address)
 		}
 		return
 	case *crisistypes.MsgVerifyInvariant:
-		res.Add(berpctypes.MessageInvolvers, msg.Sender)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Sender)
 		return
 	case *disttypes.MsgSetWithdrawAddress:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.WithdrawAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleRecipient, msg.WithdrawAddress)
 		return
 	case *disttypes.MsgWithdrawDelegatorReward:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *disttypes.MsgWithdrawValidatorCommission:
-		res.Add(berpctypes.MessageInvolvers, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *disttypes.MsgFundCommunityPool:
-		res.Add(berpctypes.MessageInvolvers, msg.Depositor)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Depositor)
 		return
 	case *evidencetypes.MsgSubmitEvidence:
-		res.Add(berpctypes.MessageInvolvers, msg.Submitter)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Submitter)
 		return
 	case *govtypesv1.MsgSubmitProposal:
-		res.Add(berpctypes.MessageInvolvers, msg.Proposer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Proposer)
 		return
 	case *govtypeslegacy.MsgSubmitProposal:
-		res.Add(berpctypes.MessageInvolvers, msg.Proposer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Proposer)
 		return
 	case *govtypesv1.MsgDeposit:
-		res.Add(berpctypes.MessageInvolvers, msg.Depositor)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Depositor)
 		return
 	case *govtypeslegacy.MsgDeposit:
-		res.Add(berpctypes.MessageInvolvers, msg.Depositor)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Depositor)
 		return
 	case *govtypesv1.MsgVote:
-		res.Add(berpctypes.MessageInvolvers, msg.Voter)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Voter)
 		return
 	case *govtypeslegacy.MsgVote:
-		res.Add(berpctypes.MessageInvolvers, msg.Voter)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Voter)
 		return
 	case *govtypesv1.MsgVoteWeighted:
-		res.Add(berpctypes.MessageInvolvers, msg.Voter)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Voter)
 		return
 	case *govtypeslegacy.MsgVoteWeighted:
-		res.Add(berpctypes.MessageInvolvers, msg.Voter)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Voter)
 		return
 	case *ibctypes.MsgCreateClient:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *ibctypes.MsgUpdateClient:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *ibctypes.MsgUpgradeClient:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *ibctypes.MsgSubmitMisbehaviour:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *ibctransfertypes.MsgTransfer:
-		res.Add(berpctypes.MessageInvolvers, msg.Sender, msg.Receiver)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Sender)
+		res.AddWithRole(berpctypes.InvolverRoleRecipient, msg.Receiver)
 		return
 	case *connectiontypes.MsgConnectionOpenAck:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *connectiontypes.MsgConnectionOpenInit:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *connectiontypes.MsgConnectionOpenConfirm:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *connectiontypes.MsgConnectionOpenTry:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *channeltypes.MsgChannelOpenInit:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *channeltypes.MsgChannelOpenConfirm:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *channeltypes.MsgChannelOpenTry:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *channeltypes.MsgAcknowledgement:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		res = res.Merge(m.getInvolversInIbcPacketInfo(msg.Packet))
 		return
 	case *channeltypes.MsgChannelOpenAck:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		return
 	case *channeltypes.MsgRecvPacket:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		res = res.Merge(m.getInvolversInIbcPacketInfo(msg.Packet))
 		return
 	case *channeltypes.MsgTimeout:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		res = res.Merge(m.getInvolversInIbcPacketInfo(msg.Packet))
 		return
 	case *channeltypes.MsgTimeoutOnClose:
-		res.Add(berpctypes.MessageInvolvers, msg.Signer)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.Signer)
 		res = res.Merge(m.getInvolversInIbcPacketInfo(msg.Packet))
 		return
 	case *slashingtypes.MsgUnjail:
-		res.Add(berpctypes.MessageInvolvers, msg.ValidatorAddr)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddr)
 		return
 	case *stakingtypes.MsgCreateValidator:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *stakingtypes.MsgEditValidator:
-		res.Add(berpctypes.MessageInvolvers, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *stakingtypes.MsgDelegate:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *stakingtypes.MsgBeginRedelegate:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorSrcAddress, msg.ValidatorDstAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorSrcAddress, msg.ValidatorDstAddress)
 		return
 	case *stakingtypes.MsgUndelegate:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *stakingtypes.MsgCancelUnbondingDelegation:
-		res.Add(berpctypes.MessageInvolvers, msg.DelegatorAddress, msg.ValidatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleSender, msg.DelegatorAddress)
+		res.AddWithRole(berpctypes.InvolverRoleValidator, msg.ValidatorAddress)
 		return
 	case *authztypes.MsgGrant:
-		res.Add(berpctypes.MessageInvolvers, msg.Granter, msg.Grantee)
+		res.AddWithRole(berpctypes.InvolverRoleGranter, msg.Granter)
+		res.AddWithRole(berpctypes.InvolverRoleGrantee, msg.Grantee)
 		return
 	case *authztypes.MsgExec:
-		res.Add(berpctypes.MessageInvolvers, msg.Grantee)
+		res.AddWithRole(berpctypes.InvolverRoleGrantee, msg.Grantee)
 
 		if len(msg.Msgs) > 0 {
 			for _, authorizedMsgAny := range msg.Msgs {
@@ -1339,7 +2077,7 @@ func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx
 				if err != nil {
 					continue
 				}
-				resChild, err := m.defaultMessageInvolversExtractor(cosmosMsg, tx, tmTx, clientCtx)
+				resChild, err := m.defaultMessageInvolversExtractor(cosmosMsg, tx, tmTx, clientCtx, committed)
 				if err != nil {
 					continue
 				}
@@ -1349,13 +2087,27 @@ func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx
 		}
 		return
 	case *authztypes.MsgRevoke:
-		res.Add(berpctypes.MessageInvolvers, msg.Granter, msg.Grantee)
+		res.AddWithRole(berpctypes.InvolverRoleGranter, msg.Granter)
+		res.AddWithRole(berpctypes.InvolverRoleGrantee, msg.Grantee)
 		return
 	default:
+		if !committed {
+			// no tx result to extract from
+			if signers, errSigners := getMsgSigners(msg); errSigners == nil {
+				accAddrPrefix := m.bech32Cfg.GetBech32AccountAddrPrefix()
+				for _, signer := range signers {
+					if signerStr, errEncode := bech32.ConvertAndEncode(accAddrPrefix, signer); errEncode == nil {
+						res.AddWithRole(berpctypes.InvolverRoleSender, signerStr)
+					}
+				}
+			}
+			return
+		}
+
 		m.GetLogger().Error("missing message involvers extractor", "msg-type", berpcutils.ProtoMessageName(msg))
 		resTxResult, errTxResult := clientCtx.Client.Tx(m.ctx, tmTx.Hash(), false)
 		if errTxResult != nil {
-			return nil, status.Error(
+			return res, status.Error(
 				codes.Internal,
 				fmt.Sprintf(
 					"failed to get tx result for tx %x when processing msg %s: %s",
@@ -1368,7 +2120,7 @@ func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx
 		for _, event := range resTxResult.TxResult.Events {
 			for _, attribute := range event.Attributes {
 				if m.bech32Cfg.IsAccountAddr(string(attribute.Value)) {
-					res.Add(berpctypes.MessageInvolvers, string(attribute.Value))
+					res.Involvers.Add(berpctypes.MessageInvolvers, string(attribute.Value))
 				}
 			}
 		}
@@ -1376,13 +2128,63 @@ func (m *Backend) defaultMessageInvolversExtractor(msg sdk.Msg, tx *tx.Tx, tmTx
 	}
 }
 
-func (m *Backend) getInvolversInIbcPacketInfo(packet channeltypes.Packet) (res berpctypes.MessageInvolversResult) {
-	res = make(berpctypes.MessageInvolversResult)
+func (m *Backend) getInvolversInIbcPacketInfo(packet channeltypes.Packet) (res berpctypes.MessageInvolversWithRoles) {
+	res = berpctypes.NewMessageInvolversWithRoles()
 
 	var data ibctransfertypes.FungibleTokenPacketData
 	if err := ibctransfertypes.ModuleCdc.UnmarshalJSON(packet.Data, &data); err == nil {
-		res.Add(berpctypes.MessageInvolvers, data.Sender, data.Receiver)
+		res.AddWithRole(berpctypes.InvolverRoleSender, data.Sender)
+		res.AddWithRole(berpctypes.InvolverRoleRecipient, data.Receiver)
 	}
 
 	return res
 }
+
+// newEvmMessageInvolversExtractor returns the built-in involvers extractor for MsgEthereumTx,
+// which extracts the sender, the recipient and the addresses within the events emitted by the message,
+// with the token involvers from the EVM logs.
+// The message events are not available for the transactions not committed, like decoded or mempool transactions,
+// only the signers are extracted in that case.
+func (m *Backend) newEvmMessageInvolversExtractor(msgEvents []abci.Event) messageInvolversWithRolesExtractor {
+	return func(msg sdk.Msg, _ *tx.Tx, _ tmtypes.Tx, _ client.Context) (res berpctypes.MessageInvolversWithRoles, err error) {
+		res = berpctypes.NewMessageInvolversWithRoles()
+
+		accAddrPrefix := m.bech32Cfg.GetBech32AccountAddrPrefix()
+
+		if signers, err := getMsgSigners(msg); err == nil {
+			for _, signer := range signers {
+				if signerStr, err := bech32.ConvertAndEncode(accAddrPrefix, signer); err == nil {
+					res.AddWithRole(berpctypes.InvolverRoleSender, signerStr)
+				}
+			}
+		}
+
+		// the fee collector refunds the unused gas, it is not involved
+		feeCollector, err := bech32.ConvertAndEncode(accAddrPrefix, authtypes.NewModuleAddress(authtypes.FeeCollectorName))
+		if err != nil {
+			return res, status.Error(codes.Internal, errors.Wrap(err, "failed to encode fee collector address").Error())
+		}
+
+		for _, event := range msgEvents {
+			if ok, kv := berpcutils.IsEventTypeWithAllAttributes(event, sdk.EventTypeMessage, sdk.AttributeKeySender); ok {
+				if sender := kv[sdk.AttributeKeySender]; sender != feeCollector && m.bech32Cfg.IsAccountAddr(sender) {
+					res.AddWithRole(berpctypes.InvolverRoleSender, sender)
+				}
+			} else if ok, kv := berpcutils.IsEventTypeWithAllAttributes(event, berpctypes.EventTypeEthereumTx, berpctypes.AttributeKeyRecipient); ok {
+				if recipient := kv[berpctypes.AttributeKeyRecipient]; common.IsHexAddress(recipient) {
+					res.AddWithRole(berpctypes.InvolverRoleRecipient, m.bech32Cfg.ConvertToAccAddressIfHexOtherwiseKeepAsIs(recipient))
+				}
+			}
+
+			for _, attribute := range event.Attributes {
+				if value := string(attribute.Value); value != feeCollector && m.bech32Cfg.IsAccountAddr(value) {
+					res.Involvers.Add(berpctypes.MessageInvolvers, value)
+				}
+			}
+		}
+
+		res.Involvers = res.Involvers.Merge(berpcutils.ExtractInvolversFromEvmLogs(berpcutils.ParseEvmLogsFromTxEvents(msgEvents)))
+
+		return
+	}
+}
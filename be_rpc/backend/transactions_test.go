@@ -0,0 +1,633 @@
+package backend
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/config"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTxSearchClient serves the tx search results of the registered queries, with the same pagination rules as Tendermint,
+// other methods are not implemented.
+type fakeTxSearchClient struct {
+	tmclient.Client
+	results map[string][]*coretypes.ResultTx
+}
+
+func (c *fakeTxSearchClient) TxSearch(_ context.Context, query string, _ bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
+	if orderBy != "desc" {
+		return nil, fmt.Errorf("unexpected order %s", orderBy)
+	}
+
+	txs := c.results[query]
+
+	pages := (len(txs) + *perPage - 1) / *perPage
+	if pages < 1 {
+		pages = 1
+	}
+	if *page < 1 || *page > pages {
+		return nil, fmt.Errorf("page should be within [1, %d] range, given %d", pages, *page)
+	}
+
+	start := (*page - 1) * *perPage
+	end := start + *perPage
+	if end > len(txs) {
+		end = len(txs)
+	}
+
+	return &coretypes.ResultTxSearch{
+		Txs:        txs[start:end],
+		TotalCount: len(txs),
+	}, nil
+}
+
+func TestBackend_searchTransactionsByAccount(t *testing.T) {
+	const account = "cosmos1account"
+	senderQuery := fmt.Sprintf("message.sender='%s'", account)
+	recipientQuery := fmt.Sprintf("transfer.recipient='%s'", account)
+
+	tmClient := &fakeTxSearchClient{
+		results: make(map[string][]*coretypes.ResultTx),
+	}
+
+	// sender of txs at even heights, recipient of txs at heights divisible by 3, multiple txs per block
+	var wantTxs []*coretypes.ResultTx
+	for height := int64(300); height > 0; height-- {
+		for txIndex := uint32(2); ; txIndex-- {
+			resTx := &coretypes.ResultTx{
+				Hash:   []byte(fmt.Sprintf("%d/%d", height, txIndex)),
+				Height: height,
+				Index:  txIndex,
+			}
+
+			isSender := height%2 == 0
+			isRecipient := height%3 == 0
+			if isSender {
+				tmClient.results[senderQuery] = append(tmClient.results[senderQuery], resTx)
+			}
+			if isRecipient {
+				tmClient.results[recipientQuery] = append(tmClient.results[recipientQuery], resTx)
+			}
+			if isSender && isRecipient {
+				bothQuery := senderQuery + " AND " + recipientQuery
+				tmClient.results[bothQuery] = append(tmClient.results[bothQuery], resTx)
+			}
+			if isSender || isRecipient {
+				wantTxs = append(wantTxs, resTx)
+			}
+
+			if txIndex == 0 {
+				break
+			}
+		}
+	}
+	require.Len(t, wantTxs, 600)
+
+	m := &Backend{
+		ctx: context.Background(),
+		clientCtx: client.Context{
+			Client: tmClient,
+		},
+	}
+
+	var gotTxs []*coretypes.ResultTx
+	for pageNo := 1; ; pageNo++ {
+		resultTxs, totalCount, err := m.searchTransactionsByAccount(account, pageNo)
+		require.NoError(t, err)
+		require.Equal(t, len(wantTxs), totalCount)
+
+		if len(resultTxs) == 0 {
+			require.Equal(t, len(wantTxs)/defaultPageSize+1, pageNo, "must be empty only after the last page")
+			break
+		}
+		require.Len(t, resultTxs, defaultPageSize, "page %d must be full", pageNo)
+
+		gotTxs = append(gotTxs, resultTxs...)
+	}
+
+	require.Equal(t, wantTxs, gotTxs, "transactions must be ordered by height & tx index descending, without duplicated or skipped")
+
+	t.Run("no transaction", func(t *testing.T) {
+		resultTxs, totalCount, err := m.searchTransactionsByAccount("cosmos1another", 1)
+		require.NoError(t, err)
+		require.Zero(t, totalCount)
+		require.Empty(t, resultTxs)
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		resultTxs, totalCount, err := m.searchTransactionsByAccount(account, 1000)
+		require.NoError(t, err)
+		require.Equal(t, len(wantTxs), totalCount)
+		require.Empty(t, resultTxs)
+	})
+}
+
+func TestBackend_SearchTransactions_PageOutOfRange(t *testing.T) {
+	const query = "tx.height=5"
+
+	tmClient := &fakeTxSearchClient{
+		results: map[string][]*coretypes.ResultTx{
+			query: {
+				{Hash: []byte("5/1"), Height: 5, Index: 1},
+				{Hash: []byte("5/0"), Height: 5, Index: 0},
+			},
+		},
+	}
+
+	m := &Backend{
+		ctx: context.Background(),
+		clientCtx: client.Context{
+			Client: tmClient,
+		},
+	}
+
+	res, err := m.SearchTransactions(query, 2, "")
+	require.NoError(t, err)
+	require.Empty(t, res["txs"])
+	require.Equal(t, 2, res["totalCount"], "total count must be kept when the page is beyond the last page")
+	require.Equal(t, 2, res["pageNo"])
+
+	res, err = m.SearchTransactions("tx.height=6", 2, "")
+	require.NoError(t, err)
+	require.Empty(t, res["txs"])
+	require.Equal(t, 0, res["totalCount"])
+}
+
+// fakeBlocksClient serves the blocks with transactions, other methods are not implemented.
+// Blocks not registered are considered missing, the block results of the failing heights are not available.
+type fakeBlocksClient struct {
+	tmclient.Client
+	tx.ServiceClient
+	blocks                  map[int64]*tx.GetBlockWithTxsResponse
+	failingBlockResults     map[int64]bool
+	responseDelayFromHeight int64
+}
+
+func (c *fakeBlocksClient) Status(_ context.Context) (*coretypes.ResultStatus, error) {
+	return &coretypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{
+			Network: "test-1",
+		},
+	}, nil
+}
+
+func (c *fakeBlocksClient) GetBlockWithTxs(_ context.Context, req *tx.GetBlockWithTxsRequest, _ ...grpc.CallOption) (*tx.GetBlockWithTxsResponse, error) {
+	// lower blocks respond slower, so the fetches are completed out of order
+	time.Sleep(time.Duration(c.responseDelayFromHeight-req.Height) * time.Millisecond)
+
+	resBlock, found := c.blocks[req.Height]
+	if !found {
+		return nil, fmt.Errorf("block %d not found", req.Height)
+	}
+	return resBlock, nil
+}
+
+func (c *fakeBlocksClient) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	if c.failingBlockResults[*height] {
+		return nil, fmt.Errorf("block results %d not available", *height)
+	}
+
+	resBlock := c.blocks[*height]
+	txsResults := make([]*abci.ResponseDeliverTx, len(resBlock.Txs))
+	for i := range txsResults {
+		txsResults[i] = &abci.ResponseDeliverTx{}
+	}
+
+	return &coretypes.ResultBlockResults{
+		Height:     *height,
+		TxsResults: txsResults,
+	}, nil
+}
+
+func TestBackend_GetTransactionsInBlockRange(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	const fromHeight, toHeight = 1, 30
+	missingHeight := int64(4)
+	errorHeight := int64(6)
+	failingBlockResultsHeight := int64(8)
+	emptyHeight := int64(10)
+
+	blocksClient := &fakeBlocksClient{
+		blocks:                  make(map[int64]*tx.GetBlockWithTxsResponse),
+		failingBlockResults:     map[int64]bool{failingBlockResultsHeight: true},
+		responseDelayFromHeight: toHeight,
+	}
+
+	wantTxHashes := make(map[int64][]string)
+	for height := int64(fromHeight); height <= toHeight; height++ {
+		if height == missingHeight {
+			continue
+		}
+
+		resBlock := &tx.GetBlockWithTxsResponse{
+			Block: &tmproto.Block{
+				Header: tmproto.Header{
+					Height: height,
+					Time:   time.Unix(height, 0),
+				},
+			},
+		}
+		blocksClient.blocks[height] = resBlock
+
+		if height == emptyHeight {
+			continue
+		}
+
+		for txIdx := 0; txIdx < 2; txIdx++ {
+			msgAny, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+				FromAddress: sdk.AccAddress(fmt.Sprintf("from-%d-%d", height, txIdx)).String(),
+				ToAddress:   sdk.AccAddress(fmt.Sprintf("to-%d-%d", height, txIdx)).String(),
+			})
+			require.NoError(t, err)
+			if height == errorHeight {
+				// not able to unpack
+				msgAny = &codectypes.Any{
+					TypeUrl: "/unknown.MsgUnknown",
+					Value:   msgAny.Value,
+				}
+			}
+
+			tmTx := tmtypes.Tx(fmt.Sprintf("tx-%d-%d", height, txIdx))
+			resBlock.Block.Data.Txs = append(resBlock.Block.Data.Txs, tmTx)
+			resBlock.Txs = append(resBlock.Txs, &tx.Tx{
+				Body: &tx.TxBody{
+					Messages: []*codectypes.Any{msgAny},
+				},
+			})
+
+			wantTxHashes[height] = append(wantTxHashes[height], strings.ToUpper(hex.EncodeToString(tmTx.Hash())))
+		}
+	}
+
+	m := &Backend{
+		ctx: context.Background(),
+		clientCtx: client.Context{
+			Client: blocksClient,
+			Codec:  cdc,
+		},
+		queryClient: &berpctypes.QueryClient{
+			ServiceClient: blocksClient,
+		},
+		logger: log.NewNopLogger(),
+		cfg: config.BeJsonRpcConfig{
+			BlockFetchConcurrency: 4,
+		},
+	}
+
+	res, err := m.GetTransactionsInBlockRange(fromHeight, toHeight)
+	require.NoError(t, err)
+
+	require.Equal(t, "test-1", res["chainId"])
+	require.Equal(t, []int64{missingHeight}, res["missingBlocks"])
+	require.Equal(t, []int64{errorHeight}, res["errorBlocks"])
+	require.NotContains(t, res, "skippedBlockRange")
+
+	blocks := res["blocks"].(map[int64]map[string]any)
+	require.Len(t, blocks, toHeight-fromHeight+1-2)
+
+	for height := int64(fromHeight); height <= toHeight; height++ {
+		if height == missingHeight || height == errorHeight {
+			require.NotContains(t, blocks, height)
+			continue
+		}
+
+		block, found := blocks[height]
+		require.True(t, found, "block %d must be returned", height)
+		require.Equal(t, height, block["timeEpochUTC"])
+
+		txs := block["txs"].([]map[string]any)
+		require.Len(t, txs, len(wantTxHashes[height]))
+		for txIdx, txInfo := range txs {
+			require.Equal(t, wantTxHashes[height][txIdx], txInfo["hash"], "transactions of block %d must be in order", height)
+			require.NotEmpty(t, txInfo["involvers"])
+		}
+	}
+
+	require.Len(t, blocks[failingBlockResultsHeight]["txs"], 2, "transactions must be returned even though the block results are not available")
+}
+
+func TestBackend_getAuthInfo(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	banktypes.RegisterInterfaces(interfaceRegistry)
+
+	m := &Backend{
+		clientCtx: client.Context{
+			Codec: codec.NewProtoCodec(interfaceRegistry),
+		},
+		logger: log.NewNopLogger(),
+	}
+
+	fromAddress := sdk.AccAddress("from").String()
+	granterAddress := sdk.AccAddress("granter").String()
+
+	newMsgSendAny := func(fromAddress string) *codectypes.Any {
+		msgAny, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+			FromAddress: fromAddress,
+			ToAddress:   sdk.AccAddress("to").String(),
+		})
+		require.NoError(t, err)
+		return msgAny
+	}
+
+	tests := []struct {
+		name        string
+		messages    []*codectypes.Any
+		fee         *tx.Fee
+		wantPayer   string
+		wantGranter string
+	}{
+		{
+			name:      "payer is the first signer when not specified",
+			messages:  []*codectypes.Any{newMsgSendAny(fromAddress)},
+			fee:       &tx.Fee{},
+			wantPayer: fromAddress,
+		},
+		{
+			name:        "payer and granter are specified",
+			messages:    []*codectypes.Any{newMsgSendAny(fromAddress)},
+			fee:         &tx.Fee{Payer: granterAddress, Granter: granterAddress},
+			wantPayer:   granterAddress,
+			wantGranter: granterAddress,
+		},
+		{
+			name:     "no message",
+			messages: nil,
+			fee:      &tx.Fee{},
+		},
+		{
+			name:        "bad payer and granter are returned as is",
+			messages:    nil,
+			fee:         &tx.Fee{Payer: "bad", Granter: "bad"},
+			wantPayer:   "bad",
+			wantGranter: "bad",
+		},
+		{
+			name:     "not able to get signers",
+			messages: []*codectypes.Any{newMsgSendAny("bad")},
+			fee:      &tx.Fee{},
+		},
+		{
+			name: "not able to unpack message",
+			messages: []*codectypes.Any{{
+				TypeUrl: "/unknown.MsgUnknown",
+			}},
+			fee: &tx.Fee{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authInfo berpctypes.GenericBackendResponse
+			require.NotPanics(t, func() {
+				authInfo = m.getAuthInfo(&tx.Tx{
+					Body: &tx.TxBody{
+						Messages: tt.messages,
+					},
+					AuthInfo: &tx.AuthInfo{
+						Fee: tt.fee,
+					},
+				})
+			})
+
+			feeInfo := authInfo["fee"].(map[string]any)
+
+			if tt.wantPayer == "" {
+				require.NotContains(t, feeInfo, "payer")
+			} else {
+				require.Equal(t, tt.wantPayer, feeInfo["payer"])
+			}
+
+			if tt.wantGranter == "" {
+				require.NotContains(t, feeInfo, "granter")
+			} else {
+				require.Equal(t, tt.wantGranter, feeInfo["granter"])
+			}
+		})
+	}
+}
+
+// fakeEvmTxIndexer serves the registered EVM transactions.
+type fakeEvmTxIndexer struct {
+	txs map[common.Hash]berpctypes.TxResultForExternal
+}
+
+func (f *fakeEvmTxIndexer) LastIndexedBlock() (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeEvmTxIndexer) GetByTxHashForExternal(hash common.Hash) (berpctypes.TxResultForExternal, error) {
+	evmTxResult, found := f.txs[hash]
+	if !found {
+		return nil, fmt.Errorf("tx not found")
+	}
+	return evmTxResult, nil
+}
+
+func (f *fakeEvmTxIndexer) GetIndexer() any {
+	return f
+}
+
+type fakeEvmTxResult struct {
+	berpctypes.TxResultForExternal
+	ethTxIndex int32
+	gasUsed    uint64
+}
+
+func (r fakeEvmTxResult) GetMsgIndex() uint32          { return 0 }
+func (r fakeEvmTxResult) GetEthTxIndex() int32         { return r.ethTxIndex }
+func (r fakeEvmTxResult) GetFailed() bool              { return false }
+func (r fakeEvmTxResult) GetGasUsed() uint64           { return r.gasUsed }
+func (r fakeEvmTxResult) GetCumulativeGasUsed() uint64 { return r.gasUsed }
+
+func TestBackend_getEvmTxInfo(t *testing.T) {
+	evmTxHash := common.HexToHash("0x0102030405060708091011121314151617181920212223242526272829303132")
+	events := []abci.Event{{
+		Type: berpctypes.EventTypeEthereumTx,
+		Attributes: []abci.EventAttribute{{
+			Key:   []byte(berpctypes.AttributeKeyEthereumTxHash),
+			Value: []byte(evmTxHash.String()),
+		}},
+	}}
+
+	t.Run("without EVM tx indexer", func(t *testing.T) {
+		m := &Backend{logger: log.NewNopLogger()}
+
+		evmTx, err := m.getEvmTxInfo(events)
+		require.NoError(t, err)
+		require.Nil(t, evmTx)
+	})
+
+	m := &Backend{
+		logger: log.NewNopLogger(),
+		externalServices: berpctypes.ExternalServices{
+			EvmTxIndexer: &fakeEvmTxIndexer{
+				txs: map[common.Hash]berpctypes.TxResultForExternal{
+					evmTxHash: fakeEvmTxResult{ethTxIndex: 1, gasUsed: 21000},
+				},
+			},
+		},
+	}
+
+	t.Run("found", func(t *testing.T) {
+		evmTx, err := m.getEvmTxInfo(events)
+		require.NoError(t, err)
+		require.Equal(t, strings.ToLower(evmTxHash.String()), evmTx["hash"])
+		require.Equal(t, int32(1), evmTx["ethTxIndex"])
+		require.Equal(t, uint64(21000), evmTx["gasUsed"])
+	})
+
+	t.Run("not indexed", func(t *testing.T) {
+		evmTx, err := m.getEvmTxInfo([]abci.Event{{
+			Type: berpctypes.EventTypeEthereumTx,
+			Attributes: []abci.EventAttribute{{
+				Key:   []byte(berpctypes.AttributeKeyEthereumTxHash),
+				Value: []byte(common.HexToHash("0x01").String()),
+			}},
+		}})
+		require.NoError(t, err)
+		require.Nil(t, evmTx)
+	})
+
+	t.Run("not EVM transaction", func(t *testing.T) {
+		evmTx, err := m.getEvmTxInfo(nil)
+		require.NoError(t, err)
+		require.Nil(t, evmTx)
+	})
+}
+
+func TestBackend_newDefaultMessageInvolversExtractor(t *testing.T) {
+	m := &Backend{
+		ctx:       context.Background(),
+		bech32Cfg: berpctypes.NewBech32Config(),
+		logger:    log.NewNopLogger(),
+		// no client, the tx result must not be queried
+	}
+
+	authority := authtypes.NewModuleAddress("gov")
+
+	// no built-in extractor for this message
+	msg := &upgradetypes.MsgSoftwareUpgrade{
+		Authority: authority.String(),
+	}
+
+	t.Run("not committed", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			res, err := m.newDefaultMessageInvolversExtractor(false)(msg, nil, tmtypes.Tx("tx"), client.Context{})
+			require.NoError(t, err)
+
+			require.Equal(t, berpctypes.MessageInvolversResult{
+				berpctypes.MessageInvolvers: {authority.String()},
+			}, res.Involvers.Finalize())
+			require.Equal(t, berpctypes.MessageInvolverRoles{
+				authority.String(): {berpctypes.InvolverRoleSender},
+			}, res.Roles.Finalize())
+		})
+	})
+}
+
+func TestBackend_newEvmMessageInvolversExtractor(t *testing.T) {
+	m := &Backend{
+		ctx:       context.Background(),
+		bech32Cfg: berpctypes.NewBech32Config(),
+		// no client, the tx result must not be queried
+	}
+
+	newEvent := func(eventType string, kv ...string) abci.Event {
+		event := abci.Event{Type: eventType}
+		for i := 0; i < len(kv); i += 2 {
+			event.Attributes = append(event.Attributes, abci.EventAttribute{Key: []byte(kv[i]), Value: []byte(kv[i+1])})
+		}
+		return event
+	}
+
+	sender := sdk.AccAddress(common.HexToAddress("0x1000000000000000000000000000000000000001").Bytes())
+	recipient := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	bankRecipient := sdk.AccAddress(common.HexToAddress("0x3000000000000000000000000000000000000003").Bytes())
+	contract := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	tokenRecipient := common.HexToAddress("0x5000000000000000000000000000000000000005")
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+
+	evmLog, err := json.Marshal(berpcutils.EvmLog{
+		Address: contract,
+		Topics: []common.Hash{
+			berpctypes.EvmEvent_Erc20_Erc721_Transfer,
+			common.BytesToHash(recipient.Bytes()),
+			common.BytesToHash(tokenRecipient.Bytes()),
+		},
+		Data: common.LeftPadBytes([]byte{1}, 32),
+	})
+	require.NoError(t, err)
+
+	msg := banktypes.NewMsgSend(sender, bankRecipient, nil)
+
+	t.Run("with message events", func(t *testing.T) {
+		msgEvents := []abci.Event{
+			newEvent(sdk.EventTypeMessage, sdk.AttributeKeyAction, "/ethermint.evm.v1.MsgEthereumTx"),
+			newEvent(berpctypes.EventTypeEthereumTx, berpctypes.AttributeKeyRecipient, recipient.String()),
+			newEvent(berpctypes.EventTypeTxLog, berpctypes.AttributeKeyTxLog, string(evmLog)),
+			newEvent(sdk.EventTypeMessage, sdk.AttributeKeySender, sender.String()),
+			newEvent(banktypes.EventTypeTransfer, banktypes.AttributeKeyRecipient, bankRecipient.String()),
+			// gas refund
+			newEvent(banktypes.EventTypeTransfer, banktypes.AttributeKeyRecipient, sender.String(), banktypes.AttributeKeySender, feeCollector.String()),
+			newEvent(sdk.EventTypeMessage, sdk.AttributeKeySender, feeCollector.String()),
+		}
+
+		res, err := m.newEvmMessageInvolversExtractor(msgEvents)(msg, nil, nil, client.Context{})
+		require.NoError(t, err)
+
+		involvers := res.Involvers.Finalize()
+		require.ElementsMatch(t, []string{
+			sender.String(),
+			sdk.AccAddress(recipient.Bytes()).String(),
+			bankRecipient.String(),
+		}, involvers[berpctypes.MessageInvolvers], "fee collector must be excluded")
+		require.ElementsMatch(t, []string{
+			sdk.AccAddress(contract.Bytes()).String(),
+			sdk.AccAddress(recipient.Bytes()).String(),
+			sdk.AccAddress(tokenRecipient.Bytes()).String(),
+		}, involvers[berpctypes.Erc20Involvers])
+
+		require.Equal(t, berpctypes.MessageInvolverRoles{
+			sender.String(): {berpctypes.InvolverRoleSender},
+			sdk.AccAddress(recipient.Bytes()).String(): {berpctypes.InvolverRoleRecipient},
+		}, res.Roles.Finalize())
+	})
+
+	t.Run("not committed", func(t *testing.T) {
+		res, err := m.newEvmMessageInvolversExtractor(nil)(msg, nil, nil, client.Context{})
+		require.NoError(t, err)
+
+		require.Equal(t, berpctypes.MessageInvolversResult{
+			berpctypes.MessageInvolvers: {sender.String()},
+		}, res.Involvers.Finalize())
+		require.Equal(t, berpctypes.MessageInvolverRoles{
+			sender.String(): {berpctypes.InvolverRoleSender},
+		}, res.Roles.Finalize())
+	})
+}
@@ -1,7 +1,10 @@
 package backend
 
 import (
+	"fmt"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"strings"
 )
 
 const defaultPageSize = 20
@@ -13,3 +16,28 @@ func getDefaultPagination(pageNo int) *query.PageRequest {
 		Reverse: true,
 	}
 }
+
+// protoTxProvider is the workaround to get access to the wrapper TxBuilder's method GetProtoTx().
+type protoTxProvider interface {
+	GetProtoTx() *tx.Tx
+}
+
+// decodeTx decodes the raw transaction bytes into the proto transaction, using the TxConfig of the client context.
+func (m *Backend) decodeTx(txBytes []byte) (*tx.Tx, error) {
+	sdkTx, err := m.clientCtx.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	protoTx, ok := sdkTx.(protoTxProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, got %T", protoTxProvider(nil), sdkTx)
+	}
+
+	return protoTx.GetProtoTx(), nil
+}
+
+// isTxSearchPageOutOfRange returns true if the error is caused by requesting a page beyond the last page of Tendermint tx search.
+func isTxSearchPageOutOfRange(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "page should be within")
+}
@@ -24,17 +24,26 @@ type BeJsonRpcConfig struct {
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
 	// AllowCORS defines if the server should allow CORS requests. Allowed by default.
 	AllowCORS bool `mapstructure:"allow-cors"`
+	// BlockFetchConcurrency is the maximum number of blocks fetched concurrently when serving a block range request.
+	BlockFetchConcurrency int `mapstructure:"block-fetch-concurrency"`
+	// MaxSSEStreams sets the maximum number of simultaneous server-sent events streams (0 = unlimited).
+	MaxSSEStreams int `mapstructure:"max-sse-streams"`
+	// IndexerEnable defines if the embedded address-to-transaction index should be enabled.
+	IndexerEnable bool `mapstructure:"indexer-enable"`
 }
 
 // DefaultBeJsonRpcConfig returns Block Explorer JSON-RPC API config with default values
 func DefaultBeJsonRpcConfig() *BeJsonRpcConfig {
 	return &BeJsonRpcConfig{
-		Enable:             DefaultEnable,
-		Address:            DefaultJSONRPCAddress,
-		HTTPTimeout:        DefaultHTTPTimeout,
-		HTTPIdleTimeout:    DefaultHTTPIdleTimeout,
-		MaxOpenConnections: DefaultMaxOpenConnections,
-		AllowCORS:          DefaultAllowCORS,
+		Enable:                DefaultEnable,
+		Address:               DefaultJSONRPCAddress,
+		HTTPTimeout:           DefaultHTTPTimeout,
+		HTTPIdleTimeout:       DefaultHTTPIdleTimeout,
+		MaxOpenConnections:    DefaultMaxOpenConnections,
+		AllowCORS:             DefaultAllowCORS,
+		BlockFetchConcurrency: DefaultBlockFetchConcurrency,
+		MaxSSEStreams:         DefaultMaxSSEStreams,
+		IndexerEnable:         DefaultIndexerEnable,
 	}
 }
 
@@ -48,18 +57,29 @@ func (c BeJsonRpcConfig) Validate() error {
 		return errors.New("BE-JSON-RPC HTTP idle timeout duration cannot be negative")
 	}
 
+	if c.BlockFetchConcurrency < 0 {
+		return errors.New("BE-JSON-RPC block fetch concurrency cannot be negative")
+	}
+
+	if c.MaxSSEStreams < 0 {
+		return errors.New("BE-JSON-RPC max SSE streams cannot be negative")
+	}
+
 	return nil
 }
 
 // GetConfig returns a fully parsed BeJsonRpcConfig object.
 func GetConfig(v *viper.Viper) (BeJsonRpcConfig, error) {
 	cfg := BeJsonRpcConfig{
-		Enable:             v.GetBool(FlagBeJsonRpcEnable),
-		Address:            v.GetString(FlagBeJsonRpcAddress),
-		HTTPTimeout:        v.GetDuration(FlagBeJsonRpcHttpTimeout),
-		HTTPIdleTimeout:    v.GetDuration(FlagBeJsonRpcHttpIdleTimeout),
-		MaxOpenConnections: v.GetInt(FlagBeJsonRpcMaxOpenConnection),
-		AllowCORS:          v.GetBool(FlagBeJsonRpcAllowCORS),
+		Enable:                v.GetBool(FlagBeJsonRpcEnable),
+		Address:               v.GetString(FlagBeJsonRpcAddress),
+		HTTPTimeout:           v.GetDuration(FlagBeJsonRpcHttpTimeout),
+		HTTPIdleTimeout:       v.GetDuration(FlagBeJsonRpcHttpIdleTimeout),
+		MaxOpenConnections:    v.GetInt(FlagBeJsonRpcMaxOpenConnection),
+		AllowCORS:             v.GetBool(FlagBeJsonRpcAllowCORS),
+		BlockFetchConcurrency: v.GetInt(FlagBeJsonRpcBlockFetchConcurrency),
+		MaxSSEStreams:         v.GetInt(FlagBeJsonRpcMaxSSEStreams),
+		IndexerEnable:         v.GetBool(FlagBeJsonRpcIndexerEnable),
 	}
 
 	return cfg, cfg.Validate()
@@ -74,6 +94,9 @@ func AddBeJsonRpcFlags(cmd *cobra.Command) {
 	cmd.Flags().Duration(FlagBeJsonRpcHttpIdleTimeout, DefaultHTTPIdleTimeout, "sets an idle timeout for Block Explorer Json-RPC http server (0 is no timeout)")
 	cmd.Flags().Duration(FlagBeJsonRpcMaxOpenConnection, DefaultMaxOpenConnections, "sets maximum open connection for Block Explorer Json-RPC http server (0 is unlimited)")
 	cmd.Flags().Bool(FlagBeJsonRpcAllowCORS, DefaultAllowCORS, "define if the Block Explorer Json-RPC should allow CORS requests")
+	cmd.Flags().Int(FlagBeJsonRpcBlockFetchConcurrency, DefaultBlockFetchConcurrency, "sets maximum number of blocks fetched concurrently when serving a block range request")
+	cmd.Flags().Int(FlagBeJsonRpcMaxSSEStreams, DefaultMaxSSEStreams, "sets maximum number of simultaneous server-sent events streams (0 is unlimited)")
+	cmd.Flags().Bool(FlagBeJsonRpcIndexerEnable, DefaultIndexerEnable, "define if the embedded address-to-transaction index should be enabled, to serve account history without tx search")
 }
 
 // GetViperConfig reads configuration parameters from Viper instance.
@@ -13,6 +13,10 @@ const (
 	FlagBeJsonRpcHttpIdleTimeout   = "be.http-idle-timeout"
 	FlagBeJsonRpcMaxOpenConnection = "be.max-open-connections"
 	FlagBeJsonRpcAllowCORS         = "be.allow-cors"
+
+	FlagBeJsonRpcBlockFetchConcurrency = "be.block-fetch-concurrency"
+	FlagBeJsonRpcMaxSSEStreams         = "be.max-sse-streams"
+	FlagBeJsonRpcIndexerEnable         = "be.indexer-enable"
 )
 
 const (
@@ -33,6 +37,15 @@ const (
 
 	// DefaultAllowCORS represents the default value for allowing CORS requests
 	DefaultAllowCORS = true
+
+	// DefaultBlockFetchConcurrency is the default maximum number of blocks fetched concurrently
+	DefaultBlockFetchConcurrency = 8
+
+	// DefaultMaxSSEStreams represents the default maximum number of simultaneous server-sent events streams
+	DefaultMaxSSEStreams = 100
+
+	// DefaultIndexerEnable is the default value for enabling the embedded address-to-transaction index
+	DefaultIndexerEnable = false
 )
 
 func bindFlagsToViper(cmd *cobra.Command, v *viper.Viper) error {
@@ -54,5 +67,14 @@ func bindFlagsToViper(cmd *cobra.Command, v *viper.Viper) error {
 	if err := v.BindPFlag("allow-cors", cmd.Flags().Lookup(FlagBeJsonRpcAllowCORS)); err != nil {
 		return err
 	}
+	if err := v.BindPFlag("block-fetch-concurrency", cmd.Flags().Lookup(FlagBeJsonRpcBlockFetchConcurrency)); err != nil {
+		return err
+	}
+	if err := v.BindPFlag("max-sse-streams", cmd.Flags().Lookup(FlagBeJsonRpcMaxSSEStreams)); err != nil {
+		return err
+	}
+	if err := v.BindPFlag("indexer-enable", cmd.Flags().Lookup(FlagBeJsonRpcIndexerEnable)); err != nil {
+		return err
+	}
 	return nil
 }
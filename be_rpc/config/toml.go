@@ -12,6 +12,7 @@ import (
 
 const (
 	DefaultConfigDirName  = "config"
+	DefaultDataDirName    = "data"
 	DefaultConfigName     = "be-json-rpc"
 	DefaultConfigFileName = DefaultConfigName + ".toml"
 )
@@ -39,6 +40,9 @@ func EnsureRoot(rootDir string, defaultConfig *BeJsonRpcConfig) {
 	if err := tmos.EnsureDir(filepath.Join(rootDir, DefaultConfigDirName), tmconfig.DefaultDirPerm); err != nil {
 		panic(err.Error())
 	}
+	if err := tmos.EnsureDir(filepath.Join(rootDir, DefaultDataDirName), tmconfig.DefaultDirPerm); err != nil {
+		panic(err.Error())
+	}
 
 	if defaultConfig == nil {
 		return
@@ -88,4 +92,14 @@ max-open-connections = {{ .MaxOpenConnections }}
 # defines if the server should allow CORS requests.
 allow-cors = {{ .AllowCORS }}
 
+# maximum number of blocks fetched concurrently when serving a block range request.
+block-fetch-concurrency = {{ .BlockFetchConcurrency }}
+
+# maximum number of simultaneous server-sent events streams (0 = unlimited).
+max-sse-streams = {{ .MaxSSEStreams }}
+
+# defines if the embedded address-to-transaction index should be enabled.
+# The index is stored within the data directory of the node home, used to serve account history without tx search.
+indexer-enable = {{ .IndexerEnable }}
+
 `
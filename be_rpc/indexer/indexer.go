@@ -0,0 +1,587 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// DbName is the name of the database of the indexer, stored within the data directory of the node home.
+	DbName = "be_indexer"
+
+	// pollInterval is the interval of checking for new blocks when the indexer has caught up with the chain.
+	pollInterval = 2 * time.Second
+	// retryInterval is the interval of retrying when failed to index a block.
+	retryInterval = 5 * time.Second
+
+	// schemaVersion is the version of the key layout and the values format,
+	// the index built with another version is dropped and rebuilt.
+	schemaVersion = 2
+	// resetBatchSize is the number of keys deleted per batch when dropping the index.
+	resetBatchSize = 10_000
+)
+
+// ErrClosed is returned when querying the indexer after it was closed.
+var ErrClosed = errors.New("indexer closed")
+
+// IndexedTx holds the involvers of a transaction to be indexed.
+// Senders and Recipients are the involvers which sent and received within the transaction,
+// they are counted into the address stats, the other involvers are only indexed.
+// Data is stored along with the transaction and returned as is when querying,
+// so the queries can be served without fetching the block, which might be pruned, from the node.
+// Undecodable marks the transaction which could not be decoded, so no involver could be extracted.
+type IndexedTx struct {
+	TxIndex     uint32
+	Hash        string
+	Involvers   []string
+	Senders     []string
+	Recipients  []string
+	Data        []byte
+	Undecodable bool
+}
+
+// AddressStats holds the number of indexed transactions involving an address, sent and received by the address,
+// with the first and last height the address was involved in.
+type AddressStats struct {
+	TxCount          uint64
+	Sent             uint64
+	Received         uint64
+	FirstSeenHeight  int64
+	LastActiveHeight int64
+}
+
+// IndexedTxRef is the reference to an indexed transaction, returned when querying the index.
+type IndexedTxRef struct {
+	Height  int64
+	TxIndex uint32
+	Hash    string
+	Data    []byte
+}
+
+// DataSource provides the blocks information to be indexed.
+type DataSource interface {
+	// GetAvailableBlockRange returns the earliest and latest block height available on the node.
+	GetAvailableBlockRange() (earliest, latest int64, err error)
+
+	// GetIndexedTxsInBlock returns the transactions within the block, with involvers.
+	GetIndexedTxsInBlock(height int64) ([]IndexedTx, error)
+}
+
+// Status is the status of the indexer.
+// CaughtUp is true once the indexer has indexed up to the latest block, the index is incomplete before that.
+// It stays true afterward, while the indexer follows the new blocks within the poll interval.
+type Status struct {
+	FirstIndexedHeight int64
+	LastIndexedHeight  int64
+	LatestBlockHeight  int64
+	UndecodableTxs     uint64
+	CaughtUp           bool
+	LastError          string
+}
+
+// Indexer indexes the transactions by involved addresses, keyed by address & height.
+// A background worker backfills the index, resumes from the last indexed height.
+type Indexer struct {
+	db     dbm.DB
+	source DataSource
+	logger log.Logger
+
+	// closeMu guards the database against being closed during the queries
+	closeMu sync.RWMutex
+	closed  bool
+
+	mu                 sync.RWMutex
+	firstIndexedHeight int64
+	lastIndexedHeight  int64
+	latestBlockHeight  int64
+	undecodableTxs     uint64
+	caughtUp           bool
+	lastError          string
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+// NewIndexer creates a new Indexer instance using the given database, the indexing progress is loaded from the database.
+// The index is dropped to be rebuilt if it was built with another schema version.
+func NewIndexer(db dbm.DB, source DataSource, logger log.Logger) (*Indexer, error) {
+	if err := resetIfSchemaChanged(db, logger); err != nil {
+		return nil, errors.Wrap(err, "failed to migrate index")
+	}
+
+	firstIndexedHeight, err := db.Get(firstIndexedHeightKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load first indexed height")
+	}
+
+	lastIndexedHeight, err := db.Get(lastIndexedHeightKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load last indexed height")
+	}
+
+	undecodableTxs, err := db.Get(undecodableTxsCountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load undecodable txs count")
+	}
+
+	return &Indexer{
+		db:                 db,
+		source:             source,
+		logger:             logger,
+		firstIndexedHeight: int64(bytesToUint64(firstIndexedHeight)),
+		lastIndexedHeight:  int64(bytesToUint64(lastIndexedHeight)),
+		undecodableTxs:     bytesToUint64(undecodableTxs),
+		stopCh:             make(chan struct{}),
+		doneCh:             make(chan struct{}),
+	}, nil
+}
+
+// Start starts the background worker, which indexes the blocks until stopped.
+func (i *Indexer) Start() {
+	i.startOnce.Do(func() {
+		go func() {
+			defer close(i.doneCh)
+			i.run()
+		}()
+	})
+}
+
+// Stop stops the background worker and waits for it to exit.
+func (i *Indexer) Stop() {
+	i.stopOnce.Do(func() {
+		close(i.stopCh)
+	})
+
+	started := true
+	i.startOnce.Do(func() {
+		started = false
+	})
+	if started {
+		<-i.doneCh
+	}
+}
+
+// Close stops the background worker then closes the database, after the in-flight queries finished.
+// The indexer is no longer caught up after closed, the later queries return ErrClosed.
+func (i *Indexer) Close() error {
+	i.Stop()
+
+	i.closeMu.Lock()
+	defer i.closeMu.Unlock()
+
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+
+	i.mu.Lock()
+	i.caughtUp = false
+	i.mu.Unlock()
+
+	return i.db.Close()
+}
+
+// GetStatus returns the current indexing progress.
+func (i *Indexer) GetStatus() Status {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return Status{
+		FirstIndexedHeight: i.firstIndexedHeight,
+		LastIndexedHeight:  i.lastIndexedHeight,
+		LatestBlockHeight:  i.latestBlockHeight,
+		UndecodableTxs:     i.undecodableTxs,
+		CaughtUp:           i.caughtUp,
+		LastError:          i.lastError,
+	}
+}
+
+// GetTransactionsByAddress returns the paginated list of the indexed transactions involving the address,
+// ordered by height & tx index descending, and the total number of transactions involving the address.
+func (i *Indexer) GetTransactionsByAddress(address string, pageNo, pageSize int) ([]IndexedTxRef, int64, error) {
+	if pageNo < 1 || pageSize < 1 {
+		return nil, 0, fmt.Errorf("invalid pagination")
+	}
+
+	i.closeMu.RLock()
+	defer i.closeMu.RUnlock()
+	if i.closed {
+		return nil, 0, ErrClosed
+	}
+
+	address = normalizeAddress(address)
+
+	stats, err := i.getAddressStats(address)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int64(stats.TxCount)
+
+	prefix := addressTxsPrefixKey(address)
+	iterator, err := i.db.ReverseIterator(prefix, prefixEndBytes(prefix))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	skip := (pageNo - 1) * pageSize
+	txs := make([]IndexedTxRef, 0)
+	for ; iterator.Valid() && len(txs) < pageSize; iterator.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		height, txIndex, ok := parseAddressTxKey(iterator.Key())
+		if !ok {
+			continue
+		}
+
+		data, err := i.db.Get(txDataKey(height, txIndex))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		txs = append(txs, IndexedTxRef{
+			Height:  height,
+			TxIndex: txIndex,
+			Hash:    string(iterator.Value()),
+			Data:    data,
+		})
+	}
+
+	return txs, total, iterator.Error()
+}
+
+// GetAddressStats returns the statistic of the indexed transactions involving the address.
+func (i *Indexer) GetAddressStats(address string) (AddressStats, error) {
+	i.closeMu.RLock()
+	defer i.closeMu.RUnlock()
+	if i.closed {
+		return AddressStats{}, ErrClosed
+	}
+
+	return i.getAddressStats(address)
+}
+
+func (i *Indexer) getAddressStats(address string) (AddressStats, error) {
+	bz, err := i.db.Get(addressStatsKey(normalizeAddress(address)))
+	if err != nil {
+		return AddressStats{}, err
+	}
+	return unmarshalAddressStats(bz), nil
+}
+
+func (i *Indexer) run() {
+	for {
+		select {
+		case <-i.stopCh:
+			return
+		default:
+		}
+
+		caughtUp, err := i.indexNextBlock()
+		if err != nil {
+			i.logger.Error("failed to index block", "error", err)
+			i.setLastError(err)
+			if !i.sleep(retryInterval) {
+				return
+			}
+			continue
+		}
+
+		if caughtUp {
+			if !i.sleep(pollInterval) {
+				return
+			}
+		}
+	}
+}
+
+// sleep returns false if the indexer was stopped during sleep.
+func (i *Indexer) sleep(duration time.Duration) bool {
+	select {
+	case <-i.stopCh:
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
+
+// indexNextBlock indexes the block next to the last indexed height, returns true if there is no block to be indexed.
+func (i *Indexer) indexNextBlock() (caughtUp bool, err error) {
+	i.mu.RLock()
+	lastIndexedHeight := i.lastIndexedHeight
+	latestBlockHeight := i.latestBlockHeight
+	i.mu.RUnlock()
+
+	var earliestBlockHeight int64
+	if lastIndexedHeight >= latestBlockHeight {
+		earliestBlockHeight, latestBlockHeight, err = i.source.GetAvailableBlockRange()
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get available block range")
+		}
+
+		caughtUp = lastIndexedHeight >= latestBlockHeight
+
+		i.mu.Lock()
+		i.latestBlockHeight = latestBlockHeight
+		if caughtUp {
+			i.caughtUp = true
+		}
+		i.mu.Unlock()
+
+		if caughtUp {
+			return true, nil
+		}
+	}
+
+	height := lastIndexedHeight + 1
+	if height < earliestBlockHeight {
+		// fresh index, or the blocks were pruned since the last run, start from the earliest block available on the node
+		height = earliestBlockHeight
+	}
+
+	txs, err := i.source.GetIndexedTxsInBlock(height)
+	if err != nil {
+		// the blocks might be pruned during backfill, skip to the earliest block available on the node
+		var errRange error
+		earliestBlockHeight, _, errRange = i.source.GetAvailableBlockRange()
+		if errRange != nil || height >= earliestBlockHeight {
+			return false, errors.Wrapf(err, "failed to get transactions in block %d", height)
+		}
+
+		i.logger.Info("blocks were pruned before indexed, skipped", "from", height, "to", earliestBlockHeight-1)
+		height = earliestBlockHeight
+
+		txs, err = i.source.GetIndexedTxsInBlock(height)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get transactions in block %d", height)
+		}
+	}
+
+	if err := i.indexBlock(height, txs); err != nil {
+		return false, errors.Wrapf(err, "failed to index block %d", height)
+	}
+
+	return false, nil
+}
+
+func (i *Indexer) indexBlock(height int64, txs []IndexedTx) error {
+	batch := i.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	var undecodableTxs uint64
+	statsByAddress := make(map[string]*AddressStats)
+	for _, tx := range txs {
+		if tx.Undecodable {
+			// not indexed, counted to be exposed via the status
+			undecodableTxs++
+			continue
+		}
+
+		senders := make(map[string]bool)
+		for _, sender := range uniqueAddresses(tx.Senders) {
+			senders[sender] = true
+		}
+
+		recipients := make(map[string]bool)
+		for _, recipient := range uniqueAddresses(tx.Recipients) {
+			recipients[recipient] = true
+		}
+
+		involvers := make([]string, 0, len(tx.Involvers)+len(tx.Senders)+len(tx.Recipients))
+		involvers = append(involvers, tx.Involvers...)
+		involvers = append(involvers, tx.Senders...)
+		involvers = append(involvers, tx.Recipients...)
+
+		if len(tx.Data) > 0 {
+			if err := batch.Set(txDataKey(height, tx.TxIndex), tx.Data); err != nil {
+				return err
+			}
+		}
+
+		for _, address := range uniqueAddresses(involvers) {
+			if err := batch.Set(addressTxKey(address, height, tx.TxIndex), []byte(tx.Hash)); err != nil {
+				return err
+			}
+
+			stats, found := statsByAddress[address]
+			if !found {
+				stats = &AddressStats{}
+				statsByAddress[address] = stats
+			}
+			stats.TxCount++
+			if senders[address] {
+				stats.Sent++
+			}
+			if recipients[address] {
+				stats.Received++
+			}
+		}
+	}
+
+	for address, blockStats := range statsByAddress {
+		stats, err := i.getAddressStats(address)
+		if err != nil {
+			return err
+		}
+
+		stats.TxCount += blockStats.TxCount
+		stats.Sent += blockStats.Sent
+		stats.Received += blockStats.Received
+		if stats.FirstSeenHeight == 0 {
+			stats.FirstSeenHeight = height
+		}
+		stats.LastActiveHeight = height
+
+		if err := batch.Set(addressStatsKey(address), marshalAddressStats(stats)); err != nil {
+			return err
+		}
+	}
+
+	i.mu.RLock()
+	firstIndexedHeight := i.firstIndexedHeight
+	undecodableTxs += i.undecodableTxs
+	i.mu.RUnlock()
+
+	if firstIndexedHeight == 0 {
+		firstIndexedHeight = height
+		if err := batch.Set(firstIndexedHeightKey, uint64ToBytes(uint64(height))); err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(lastIndexedHeightKey, uint64ToBytes(uint64(height))); err != nil {
+		return err
+	}
+	if err := batch.Set(undecodableTxsCountKey, uint64ToBytes(undecodableTxs)); err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	i.firstIndexedHeight = firstIndexedHeight
+	i.lastIndexedHeight = height
+	i.undecodableTxs = undecodableTxs
+	i.lastError = ""
+	i.mu.Unlock()
+
+	return nil
+}
+
+func (i *Indexer) setLastError(err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.lastError = err.Error()
+}
+
+// resetIfSchemaChanged drops the index built with another schema version, then records the current version.
+func resetIfSchemaChanged(db dbm.DB, logger log.Logger) error {
+	bz, err := db.Get(schemaVersionKey)
+	if err != nil {
+		return err
+	}
+
+	version := bytesToUint64(bz)
+	if version == schemaVersion {
+		return nil
+	}
+
+	empty, err := isEmpty(db)
+	if err != nil {
+		return err
+	}
+
+	if !empty {
+		logger.Info("index schema changed, dropping index to be rebuilt", "from", version, "to", schemaVersion)
+
+		for {
+			keys, err := firstKeys(db, resetBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				break
+			}
+
+			if err := deleteKeys(db, keys); err != nil {
+				return err
+			}
+		}
+	}
+
+	return db.SetSync(schemaVersionKey, uint64ToBytes(schemaVersion))
+}
+
+func isEmpty(db dbm.DB) (bool, error) {
+	keys, err := firstKeys(db, 1)
+	return len(keys) == 0, err
+}
+
+func firstKeys(db dbm.DB, limit int) ([][]byte, error) {
+	iterator, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iterator.Close()
+	}()
+
+	var keys [][]byte
+	for ; iterator.Valid() && len(keys) < limit; iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	return keys, iterator.Error()
+}
+
+func deleteKeys(db dbm.DB, keys [][]byte) error {
+	batch := db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func uniqueAddresses(addresses []string) []string {
+	unique := make(map[string]bool)
+	for _, address := range addresses {
+		address = normalizeAddress(address)
+		if len(address) == 0 {
+			continue
+		}
+		unique[address] = true
+	}
+
+	res := make([]string, 0, len(unique))
+	for address := range unique {
+		res = append(res, address)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
@@ -0,0 +1,283 @@
+package indexer
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"testing"
+)
+
+type fakeDataSource struct {
+	earliest int64
+	latest   int64
+	blocks   map[int64][]IndexedTx
+}
+
+func (s *fakeDataSource) GetAvailableBlockRange() (earliest, latest int64, err error) {
+	return s.earliest, s.latest, nil
+}
+
+func (s *fakeDataSource) GetIndexedTxsInBlock(height int64) ([]IndexedTx, error) {
+	if height < s.earliest || height > s.latest {
+		return nil, fmt.Errorf("block %d not available", height)
+	}
+	return s.blocks[height], nil
+}
+
+func indexAll(t *testing.T, idx *Indexer) {
+	for {
+		caughtUp, err := idx.indexNextBlock()
+		require.NoError(t, err)
+		if caughtUp {
+			return
+		}
+	}
+}
+
+func TestIndexer(t *testing.T) {
+	source := &fakeDataSource{
+		earliest: 5,
+		latest:   7,
+		blocks: map[int64][]IndexedTx{
+			5: {
+				{TxIndex: 0, Hash: "A", Involvers: []string{"addr1", "addr2"}, Senders: []string{"addr1"}, Recipients: []string{"addr2"}, Data: []byte("data-A")},
+				{TxIndex: 1, Hash: "B", Involvers: []string{"addr1", " ADDR1 ", ""}},
+			},
+			7: {
+				{TxIndex: 0, Hash: "C", Recipients: []string{"ADDR2"}},
+				{TxIndex: 1, Hash: "D", Involvers: []string{"addr1"}, Senders: []string{"ADDR1", "addr3"}},
+				{TxIndex: 2, Hash: "X", Undecodable: true},
+			},
+		},
+	}
+
+	db := dbm.NewMemDB()
+	idx, err := NewIndexer(db, source, log.NewNopLogger())
+	require.NoError(t, err)
+
+	indexAll(t, idx)
+
+	require.Equal(t, Status{
+		FirstIndexedHeight: 5,
+		LastIndexedHeight:  7,
+		LatestBlockHeight:  7,
+		UndecodableTxs:     1,
+		CaughtUp:           true,
+	}, idx.GetStatus())
+
+	t.Run("ordered by height and tx index descending", func(t *testing.T) {
+		txs, total, err := idx.GetTransactionsByAddress("addr1", 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+		require.Equal(t, []IndexedTxRef{
+			{Height: 7, TxIndex: 1, Hash: "D"},
+			{Height: 5, TxIndex: 1, Hash: "B"},
+			{Height: 5, TxIndex: 0, Hash: "A", Data: []byte("data-A")},
+		}, txs, "data must be returned as stored")
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		txs, total, err := idx.GetTransactionsByAddress("addr1", 2, 2)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+		require.Equal(t, []IndexedTxRef{{Height: 5, TxIndex: 0, Hash: "A", Data: []byte("data-A")}}, txs)
+
+		txs, _, err = idx.GetTransactionsByAddress("addr1", 3, 2)
+		require.NoError(t, err)
+		require.Empty(t, txs)
+	})
+
+	t.Run("address is not a prefix match", func(t *testing.T) {
+		txs, total, err := idx.GetTransactionsByAddress("addr", 1, 10)
+		require.NoError(t, err)
+		require.Zero(t, total)
+		require.Empty(t, txs)
+	})
+
+	t.Run("address stats", func(t *testing.T) {
+		stats, err := idx.GetAddressStats("addr1")
+		require.NoError(t, err)
+		require.Equal(t, AddressStats{
+			TxCount:          3,
+			Sent:             2,
+			FirstSeenHeight:  5,
+			LastActiveHeight: 7,
+		}, stats, "involved without recipient role must not be counted as received")
+
+		stats, err = idx.GetAddressStats("addr2")
+		require.NoError(t, err)
+		require.Equal(t, AddressStats{
+			TxCount:          2,
+			Received:         2,
+			FirstSeenHeight:  5,
+			LastActiveHeight: 7,
+		}, stats, "recipients must be indexed as well")
+
+		stats, err = idx.GetAddressStats("addr3")
+		require.NoError(t, err)
+		require.Equal(t, AddressStats{
+			TxCount:          1,
+			Sent:             1,
+			FirstSeenHeight:  7,
+			LastActiveHeight: 7,
+		}, stats, "senders must be indexed as well")
+
+		stats, err = idx.GetAddressStats("addr4")
+		require.NoError(t, err)
+		require.Equal(t, AddressStats{}, stats)
+	})
+
+	t.Run("resume from last indexed height", func(t *testing.T) {
+		source.latest = 8
+		source.blocks[8] = []IndexedTx{{TxIndex: 0, Hash: "E", Involvers: []string{"addr2"}}}
+
+		resumed, err := NewIndexer(db, source, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Equal(t, int64(7), resumed.GetStatus().LastIndexedHeight)
+		require.Equal(t, uint64(1), resumed.GetStatus().UndecodableTxs)
+		require.False(t, resumed.GetStatus().CaughtUp, "latest block height is not known yet")
+
+		indexAll(t, resumed)
+
+		txs, total, err := resumed.GetTransactionsByAddress("addr2", 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+		require.Equal(t, []IndexedTxRef{
+			{Height: 8, TxIndex: 0, Hash: "E"},
+			{Height: 7, TxIndex: 0, Hash: "C"},
+			{Height: 5, TxIndex: 0, Hash: "A", Data: []byte("data-A")},
+		}, txs)
+	})
+}
+
+func TestIndexer_CaughtUp(t *testing.T) {
+	source := &fakeDataSource{earliest: 1, latest: 2}
+
+	idx, err := NewIndexer(dbm.NewMemDB(), source, log.NewNopLogger())
+	require.NoError(t, err)
+
+	indexAll(t, idx)
+	require.True(t, idx.GetStatus().CaughtUp)
+
+	// new blocks produced, known before indexed
+	source.latest = 4
+	caughtUp, err := idx.indexNextBlock()
+	require.NoError(t, err)
+	require.False(t, caughtUp)
+	require.Equal(t, int64(4), idx.GetStatus().LatestBlockHeight)
+	require.Equal(t, int64(3), idx.GetStatus().LastIndexedHeight)
+	require.True(t, idx.GetStatus().CaughtUp, "must stay caught up while following the new blocks")
+}
+
+func TestIndexer_PrunedDuringBackfill(t *testing.T) {
+	source := &fakeDataSource{
+		earliest: 1,
+		latest:   10,
+		blocks: map[int64][]IndexedTx{
+			1: {{TxIndex: 0, Hash: "A", Senders: []string{"addr1"}}},
+			8: {{TxIndex: 0, Hash: "B", Senders: []string{"addr1"}}},
+		},
+	}
+
+	idx, err := NewIndexer(dbm.NewMemDB(), source, log.NewNopLogger())
+	require.NoError(t, err)
+
+	caughtUp, err := idx.indexNextBlock()
+	require.NoError(t, err)
+	require.False(t, caughtUp)
+	require.Equal(t, int64(1), idx.GetStatus().LastIndexedHeight)
+
+	// the node pruned the blocks before the indexer reached them
+	source.earliest = 8
+
+	indexAll(t, idx)
+	require.Equal(t, int64(10), idx.GetStatus().LastIndexedHeight)
+
+	txs, total, err := idx.GetTransactionsByAddress("addr1", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Equal(t, []IndexedTxRef{
+		{Height: 8, TxIndex: 0, Hash: "B"},
+		{Height: 1, TxIndex: 0, Hash: "A"},
+	}, txs)
+}
+
+func TestIndexer_Close(t *testing.T) {
+	source := &fakeDataSource{earliest: 1, latest: 3}
+
+	db := dbm.NewMemDB()
+	idx, err := NewIndexer(db, source, log.NewNopLogger())
+	require.NoError(t, err)
+
+	idx.Start()
+	require.NoError(t, idx.Close())
+	require.False(t, idx.GetStatus().CaughtUp, "must not be served after closed")
+
+	// the worker exited, stop again is no-op
+	idx.Stop()
+
+	// the database is closed, queries are rejected
+	_, _, err = idx.GetTransactionsByAddress("addr1", 1, 10)
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = idx.GetAddressStats("addr1")
+	require.ErrorIs(t, err, ErrClosed)
+
+	// close again is no-op
+	require.NoError(t, idx.Close())
+
+	t.Run("not started", func(t *testing.T) {
+		idx, err := NewIndexer(dbm.NewMemDB(), source, log.NewNopLogger())
+		require.NoError(t, err)
+		require.NoError(t, idx.Close())
+	})
+}
+
+func TestIndexer_SchemaVersion(t *testing.T) {
+	source := &fakeDataSource{
+		earliest: 1,
+		latest:   1,
+		blocks: map[int64][]IndexedTx{
+			1: {{TxIndex: 0, Hash: "A", Senders: []string{"addr1"}}},
+		},
+	}
+
+	t.Run("index of the same version is kept", func(t *testing.T) {
+		db := dbm.NewMemDB()
+		idx, err := NewIndexer(db, source, log.NewNopLogger())
+		require.NoError(t, err)
+		indexAll(t, idx)
+
+		reopened, err := NewIndexer(db, source, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Equal(t, int64(1), reopened.GetStatus().LastIndexedHeight)
+
+		_, total, err := reopened.GetTransactionsByAddress("addr1", 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), total)
+	})
+
+	t.Run("index of another version is dropped", func(t *testing.T) {
+		// built without schema version, stats in the legacy format
+		db := dbm.NewMemDB()
+		require.NoError(t, db.Set(lastIndexedHeightKey, uint64ToBytes(1)))
+		require.NoError(t, db.Set(firstIndexedHeightKey, uint64ToBytes(1)))
+		require.NoError(t, db.Set(addressTxKey("addr1", 1, 0), []byte("A")))
+		require.NoError(t, db.Set(addressStatsKey("addr1"), make([]byte, 32)))
+
+		idx, err := NewIndexer(db, source, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Equal(t, Status{}, idx.GetStatus(), "must be indexed from scratch")
+
+		bz, err := db.Get(schemaVersionKey)
+		require.NoError(t, err)
+		require.Equal(t, uint64(schemaVersion), bytesToUint64(bz))
+
+		indexAll(t, idx)
+
+		txs, total, err := idx.GetTransactionsByAddress("addr1", 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), total)
+		require.Equal(t, []IndexedTxRef{{Height: 1, TxIndex: 0, Hash: "A"}}, txs)
+	})
+}
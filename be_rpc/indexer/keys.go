@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"encoding/binary"
+)
+
+// Key layout:
+// - schemaVersionKey => big-endian uint64 of the schema version, see schemaVersion
+// - lastIndexedHeightKey => big-endian uint64 of the last indexed height
+// - firstIndexedHeightKey => big-endian uint64 of the first indexed height
+// - undecodableTxsCountKey => big-endian uint64 of the number of transactions could not be decoded
+// - addressTxPrefix | address | 0x00 | height (8 bytes) | tx index (4 bytes) => tx hash
+// - txDataPrefix | height (8 bytes) | tx index (4 bytes) => data of the tx, see IndexedTx.Data
+// - addressStatsPrefix | address => AddressStats of the address
+
+var (
+	schemaVersionKey       = []byte{0x00}
+	lastIndexedHeightKey   = []byte{0x01}
+	firstIndexedHeightKey  = []byte{0x02}
+	undecodableTxsCountKey = []byte{0x03}
+	addressTxPrefix        = []byte{0x10}
+	txDataPrefix           = []byte{0x11}
+	addressStatsPrefix     = []byte{0x12}
+)
+
+const addressSeparator = 0x00
+
+// addressTxsPrefixKey returns the prefix of all the indexed txs involving the address.
+func addressTxsPrefixKey(address string) []byte {
+	key := make([]byte, 0, len(addressTxPrefix)+len(address)+1)
+	key = append(key, addressTxPrefix...)
+	key = append(key, []byte(address)...)
+	key = append(key, addressSeparator)
+	return key
+}
+
+func addressTxKey(address string, height int64, txIndex uint32) []byte {
+	key := addressTxsPrefixKey(address)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	key = binary.BigEndian.AppendUint32(key, txIndex)
+	return key
+}
+
+// parseAddressTxKey returns the height and tx index from the key built by addressTxKey.
+func parseAddressTxKey(key []byte) (height int64, txIndex uint32, ok bool) {
+	if len(key) < 12 {
+		return 0, 0, false
+	}
+	suffix := key[len(key)-12:]
+	return int64(binary.BigEndian.Uint64(suffix[:8])), binary.BigEndian.Uint32(suffix[8:]), true
+}
+
+func txDataKey(height int64, txIndex uint32) []byte {
+	key := make([]byte, 0, len(txDataPrefix)+12)
+	key = append(key, txDataPrefix...)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	key = binary.BigEndian.AppendUint32(key, txIndex)
+	return key
+}
+
+func addressStatsKey(address string) []byte {
+	key := make([]byte, 0, len(addressStatsPrefix)+len(address))
+	key = append(key, addressStatsPrefix...)
+	key = append(key, []byte(address)...)
+	return key
+}
+
+func marshalAddressStats(stats AddressStats) []byte {
+	bz := make([]byte, 0, 40)
+	bz = binary.BigEndian.AppendUint64(bz, stats.TxCount)
+	bz = binary.BigEndian.AppendUint64(bz, stats.Sent)
+	bz = binary.BigEndian.AppendUint64(bz, stats.Received)
+	bz = binary.BigEndian.AppendUint64(bz, uint64(stats.FirstSeenHeight))
+	bz = binary.BigEndian.AppendUint64(bz, uint64(stats.LastActiveHeight))
+	return bz
+}
+
+func unmarshalAddressStats(bz []byte) AddressStats {
+	if len(bz) != 40 {
+		return AddressStats{}
+	}
+	return AddressStats{
+		TxCount:          binary.BigEndian.Uint64(bz[0:8]),
+		Sent:             binary.BigEndian.Uint64(bz[8:16]),
+		Received:         binary.BigEndian.Uint64(bz[16:24]),
+		FirstSeenHeight:  int64(binary.BigEndian.Uint64(bz[24:32])),
+		LastActiveHeight: int64(binary.BigEndian.Uint64(bz[32:40])),
+	}
+}
+
+func uint64ToBytes(value uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, value)
+}
+
+func bytesToUint64(bz []byte) uint64 {
+	if len(bz) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// prefixEndBytes returns the end key of the iteration over the given prefix.
+func prefixEndBytes(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
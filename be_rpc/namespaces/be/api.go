@@ -5,24 +5,29 @@ import (
 	"github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/backend"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 )
 
 // API is the Block Explorer JSON-RPC.
 type API struct {
-	ctx     *server.Context
-	logger  log.Logger
-	backend backend.BackendI
+	ctx          *server.Context
+	logger       log.Logger
+	backend      backend.BackendI
+	newBlockFeed *newBlockFeed
 }
 
 // NewBeAPI creates an instance of the Block Explorer API.
 func NewBeAPI(
 	ctx *server.Context,
 	backend backend.BackendI,
+	tmWSClient *rpcclient.WSClient,
 ) *API {
+	logger := ctx.Logger.With("api", "be")
 	return &API{
-		ctx:     ctx,
-		logger:  ctx.Logger.With("api", "be"),
-		backend: backend,
+		ctx:          ctx,
+		logger:       logger,
+		backend:      backend,
+		newBlockFeed: newNewBlockFeed(tmWSClient, logger),
 	}
 }
 
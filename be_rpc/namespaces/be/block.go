@@ -2,7 +2,12 @@ package be
 
 import berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 
-func (api *API) GetBlockByNumber(height int64) (berpctypes.GenericBackendResponse, error) {
+func (api *API) GetBlockByNumber(blockNumber berpctypes.BlockNumber, eventTypesOptional *[]string) (berpctypes.GenericBackendResponse, error) {
 	api.logger.Debug("be_getBlockByNumber")
-	return api.backend.GetBlockByNumber(height)
+	return api.backend.GetBlockByNumber(blockNumber, getEventTypes(eventTypesOptional))
+}
+
+func (api *API) GetBlockByHash(hash string, eventTypesOptional *[]string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getBlockByHash")
+	return api.backend.GetBlockByHash(hash, getEventTypes(eventTypesOptional))
 }
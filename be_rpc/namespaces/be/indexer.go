@@ -0,0 +1,8 @@
+package be
+
+import berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+
+func (api *API) GetIndexerStatus() (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getIndexerStatus")
+	return api.backend.GetIndexerStatus()
+}
@@ -0,0 +1,207 @@
+package be
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"sync"
+	"time"
+)
+
+// newBlockFeed subscribes to the new block events via the Tendermint websocket client,
+// and broadcasts the height of the new blocks to the subscribers.
+// The feed stops when the Tendermint websocket client stopped, after failed to reconnect,
+// the channels of the subscribers are closed then.
+type newBlockFeed struct {
+	logger     log.Logger
+	tmWSClient *rpcclient.WSClient
+
+	startMutex *sync.Mutex // guards the Tendermint subscription
+	started    bool
+
+	mutex            *sync.Mutex // guards the subscribers
+	subscribers      map[uint64]chan int64
+	nextSubscriberId uint64
+	stopErr          error
+	stopCh           chan struct{}
+}
+
+// subscriberBufferSize is the buffer size of the channel of each subscriber,
+// new heights are dropped if the subscriber is slow, subscribers should catch up using the height gap.
+const subscriberBufferSize = 16
+
+const (
+	// reconnectionCheckInterval is the interval of checking whether the Tendermint websocket client reconnected.
+	reconnectionCheckInterval = 500 * time.Millisecond
+	// subscribeTimeout is the timeout of subscribing the new block events via the Tendermint websocket client.
+	subscribeTimeout = 10 * time.Second
+)
+
+func newNewBlockFeed(tmWSClient *rpcclient.WSClient, logger log.Logger) *newBlockFeed {
+	return &newBlockFeed{
+		logger:      logger,
+		tmWSClient:  tmWSClient,
+		startMutex:  &sync.Mutex{},
+		mutex:       &sync.Mutex{},
+		subscribers: make(map[uint64]chan int64),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// subscribe registers a new subscriber, the Tendermint subscription is started on the first call.
+// The channel is closed when the feed stopped.
+func (f *newBlockFeed) subscribe() (heights <-chan int64, unsubscribe func(), err error) {
+	if f.tmWSClient == nil {
+		return nil, nil, errors.New("Tendermint websocket client is not available")
+	}
+
+	if err := f.start(); err != nil {
+		return nil, nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.stopErr != nil {
+		return nil, nil, f.stopErr
+	}
+
+	subscriberId := f.nextSubscriberId
+	f.nextSubscriberId++
+
+	ch := make(chan int64, subscriberBufferSize)
+	f.subscribers[subscriberId] = ch
+
+	unsubscribe = func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		delete(f.subscribers, subscriberId)
+	}
+
+	return ch, unsubscribe, nil
+}
+
+// start subscribes the new block events on the first call, the callers wait for the first subscription to complete.
+// The subscribers are not blocked from being fed meanwhile.
+func (f *newBlockFeed) start() error {
+	f.startMutex.Lock()
+	defer f.startMutex.Unlock()
+
+	if f.started {
+		return nil
+	}
+
+	if err := f.subscribeNewBlockEvents(); err != nil {
+		return err
+	}
+
+	go f.consumeTendermintResponses()
+	go f.watchReconnection()
+	f.started = true
+
+	return nil
+}
+
+func (f *newBlockFeed) subscribeNewBlockEvents() error {
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	defer cancel()
+
+	err := f.tmWSClient.Subscribe(ctx, tmtypes.QueryForEvent(tmtypes.EventNewBlock).String())
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe new block events")
+	}
+	return nil
+}
+
+// watchReconnection re-subscribes the new block events after the Tendermint websocket client reconnected,
+// because the subscriptions are not restored by the client.
+func (f *newBlockFeed) watchReconnection() {
+	ticker := time.NewTicker(reconnectionCheckInterval)
+	defer ticker.Stop()
+
+	var reconnecting bool
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if f.tmWSClient.IsReconnecting() {
+			reconnecting = true
+			continue
+		}
+
+		if !reconnecting {
+			continue
+		}
+		reconnecting = false
+
+		if err := f.subscribeNewBlockEvents(); err != nil {
+			f.logger.Error("failed to re-subscribe new block events after reconnected", "error", err)
+			continue
+		}
+		f.logger.Info("re-subscribed new block events after reconnected")
+	}
+}
+
+func (f *newBlockFeed) consumeTendermintResponses() {
+	for response := range f.tmWSClient.ResponsesCh {
+		if response.Error != nil {
+			f.logger.Error("error response from Tendermint websocket", "error", response.Error.Error())
+			continue
+		}
+
+		var resultEvent coretypes.ResultEvent
+		if err := tmjson.Unmarshal(response.Result, &resultEvent); err != nil {
+			f.logger.Debug("failed to unmarshal Tendermint websocket response", "error", err)
+			continue
+		}
+
+		eventDataNewBlock, ok := resultEvent.Data.(tmtypes.EventDataNewBlock)
+		if !ok || eventDataNewBlock.Block == nil {
+			continue // subscription confirmation or other events
+		}
+
+		f.broadcast(eventDataNewBlock.Block.Height)
+	}
+
+	// the responses channel is closed when the client stopped, after failed to reconnect
+	f.logger.Error("Tendermint websocket client stopped, new block feed stopped")
+	f.stop(errors.New("Tendermint websocket client stopped"))
+}
+
+// stop closes the channels of the subscribers, the new subscriptions are rejected with the given error.
+func (f *newBlockFeed) stop(err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.stopErr != nil {
+		return
+	}
+
+	f.stopErr = err
+	for subscriberId, ch := range f.subscribers {
+		close(ch)
+		delete(f.subscribers, subscriberId)
+	}
+	close(f.stopCh)
+}
+
+func (f *newBlockFeed) broadcast(height int64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, ch := range f.subscribers {
+		select {
+		case ch <- height:
+		default:
+			// drop, subscriber is slow
+		}
+	}
+}
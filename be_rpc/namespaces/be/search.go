@@ -0,0 +1,8 @@
+package be
+
+import berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+
+func (api *API) Search(query string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_search")
+	return api.backend.Search(query)
+}
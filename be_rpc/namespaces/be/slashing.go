@@ -0,0 +1,14 @@
+package be
+
+import berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+
+func (api *API) GetValidatorsUptime(pageNoOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getValidatorsUptime")
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.backend.GetValidatorsUptime(pageNo)
+}
@@ -11,3 +11,41 @@ func (api *API) GetValidators() (berpctypes.GenericBackendResponse, error) {
 	api.logger.Debug("be_getValidators")
 	return api.backend.GetValidators()
 }
+
+func (api *API) GetStakingValidators(statusOptional *string, pageNoOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getStakingValidators")
+
+	var status string
+	if statusOptional != nil {
+		status = *statusOptional
+	}
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.backend.GetStakingValidators(status, pageNo)
+}
+
+func (api *API) GetValidatorDelegations(valAddr string, pageNoOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getValidatorDelegations")
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.backend.GetValidatorDelegations(valAddr, pageNo)
+}
+
+func (api *API) GetValidatorUnbondingDelegations(valAddr string, pageNoOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getValidatorUnbondingDelegations")
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.backend.GetValidatorUnbondingDelegations(valAddr, pageNo)
+}
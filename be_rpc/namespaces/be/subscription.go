@@ -0,0 +1,215 @@
+package be
+
+import (
+	"context"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
+	berpcutils "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"strings"
+)
+
+// maxCatchUpBlocks is the maximum number of missed blocks to be notified when the subscriber falls behind.
+const maxCatchUpBlocks = 10
+
+// NewBlocks notifies the new blocks, in the same format as be_getBlockByNumber.
+// Subscribe via: be_subscribe("newBlocks")
+func (api *API) NewBlocks(ctx context.Context) (*rpc.Subscription, error) {
+	api.logger.Debug("be_subscribe newBlocks")
+
+	return api.subscribeNewBlocks(ctx, func(notify func(data any) error, height int64) error {
+		block, err := api.backend.GetBlockByNumber(berpctypes.BlockNumber(height), nil)
+		if err != nil {
+			return err
+		}
+
+		return notify(block)
+	})
+}
+
+// NewBlockHeights notifies the height of the new blocks, as a lightweight alternative of newBlocks.
+// Subscribe via: be_subscribe("newBlockHeights")
+func (api *API) NewBlockHeights(ctx context.Context) (*rpc.Subscription, error) {
+	api.logger.Debug("be_subscribe newBlockHeights")
+
+	return api.subscribeNewBlocks(ctx, func(notify func(data any) error, height int64) error {
+		return notify(map[string]any{
+			"height": height,
+		})
+	})
+}
+
+// NewTransactions notifies the transactions in new blocks,
+// in the same format as transactions returned by be_getTransactionsInBlockRange.
+// Subscribe via: be_subscribe("newTransactions")
+func (api *API) NewTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	api.logger.Debug("be_subscribe newTransactions")
+
+	return api.subscribeNewBlocks(ctx, func(notify func(data any) error, height int64) error {
+		txs, err := api.getTransactionsInBlock(height)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range txs {
+			if err := notify(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AccountTransactions notifies the transactions in new blocks which involve the given account,
+// in the same format as transactions returned by be_getTransactionsInBlockRange.
+// Accepts bech32 account address, validator operator address or 0x address.
+// Subscribe via: be_subscribe("accountTransactions", address)
+func (api *API) AccountTransactions(ctx context.Context, accountAddressStr string) (*rpc.Subscription, error) {
+	api.logger.Debug("be_subscribe accountTransactions")
+
+	accountAddressStr = berpcutils.NormalizeAddress(accountAddressStr)
+	if strings.HasPrefix(accountAddressStr, "0x") && !common.IsHexAddress(accountAddressStr) {
+		return nil, berpctypes.ErrBadAddress
+	}
+
+	bech32Cfg := api.backend.GetBech32Config()
+	accAddrStr := bech32Cfg.FromAnyToBech32AccountAddrUnsafe(accountAddressStr)
+	if !bech32Cfg.IsAccountAddr(accAddrStr) {
+		return nil, berpctypes.ErrBadAddress
+	}
+	accAddr, err := sdk.GetFromBech32(accAddrStr, bech32Cfg.GetBech32AccountAddrPrefix())
+	if err != nil {
+		return nil, berpctypes.ErrBadAddress
+	}
+	valAddrStr, err := bech32.ConvertAndEncode(bech32Cfg.GetBech32ValidatorAddrPrefix(), accAddr)
+	if err != nil {
+		return nil, berpctypes.ErrBadAddress
+	}
+
+	// involvers can be either account or validator address
+	watchAddresses := map[string]bool{
+		accAddrStr: true,
+		valAddrStr: true,
+	}
+
+	return api.subscribeNewBlocks(ctx, func(notify func(data any) error, height int64) error {
+		txs, err := api.getTransactionsInBlock(height)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range txs {
+			if !isTransactionInvolvesAny(tx, watchAddresses) {
+				continue
+			}
+
+			if err := notify(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// subscribeNewBlocks creates a subscription which invokes the processor for every new block.
+func (api *API) subscribeNewBlocks(
+	ctx context.Context,
+	processor func(notify func(data any) error, height int64) error,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	heights, unsubscribe, err := api.newBlockFeed.subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	notify := func(data any) error {
+		return notifier.Notify(rpcSub.ID, data)
+	}
+
+	go func() {
+		defer unsubscribe()
+
+		var lastHeight int64
+		for {
+			select {
+			case height, ok := <-heights:
+				if !ok {
+					// the feed stopped, report to the subscriber as no more block will be notified
+					if err := notify(map[string]any{
+						"error": "new block feed stopped",
+					}); err != nil {
+						api.logger.Error("failed to notify subscription", "subscription", rpcSub.ID, "error", err)
+					}
+					return
+				}
+
+				fromHeight := height
+				if lastHeight > 0 && height > lastHeight+1 {
+					fromHeight = lastHeight + 1
+				}
+				if height-fromHeight+1 > maxCatchUpBlocks {
+					fromHeight = height - maxCatchUpBlocks + 1
+				}
+
+				for h := fromHeight; h <= height; h++ {
+					if err := processor(notify, h); err != nil {
+						api.logger.Error("failed to notify subscription", "subscription", rpcSub.ID, "height", h, "error", err)
+					}
+				}
+
+				if height > lastHeight {
+					lastHeight = height
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// getTransactionsInBlock returns the transactions in the given block, with height and block time appended.
+func (api *API) getTransactionsInBlock(height int64) ([]map[string]any, error) {
+	res, err := api.backend.GetTransactionsInBlockRange(height, height)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, _ := res["blocks"].(map[int64]map[string]any)
+	block, found := blocks[height]
+	if !found {
+		return nil, nil
+	}
+
+	txs, _ := block["txs"].([]map[string]any)
+	for _, tx := range txs {
+		tx["height"] = height
+		tx["timeEpochUTC"] = block["timeEpochUTC"]
+	}
+
+	return txs, nil
+}
+
+func isTransactionInvolvesAny(tx map[string]any, addresses map[string]bool) bool {
+	involvers, _ := tx["involvers"].(berpctypes.MessageInvolversResult)
+	for _, involverAddresses := range involvers {
+		for _, address := range involverAddresses {
+			if addresses[address] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -18,3 +18,64 @@ func (api *API) GetTransactionByHash(hash string) (berpctypes.GenericBackendResp
 	api.logger.Debug("be_getTransactionByHash")
 	return api.backend.GetTransactionByHash(hash)
 }
+
+func (api *API) GetTransactionsByAccount(accountAddressStr string, pageNoOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getTransactionsByAccount")
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.backend.GetTransactionsByAccount(accountAddressStr, pageNo)
+}
+
+func (api *API) DecodeTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_decodeTransaction")
+	return api.backend.DecodeTransaction(base64OrHexTxBytes)
+}
+
+func (api *API) SimulateTransaction(base64OrHexTxBytes string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_simulateTransaction")
+	return api.backend.SimulateTransaction(base64OrHexTxBytes)
+}
+
+func (api *API) BroadcastTransaction(base64OrHexTxBytes string, modeOptional *string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_broadcastTransaction")
+
+	var mode string
+	if modeOptional != nil {
+		mode = *modeOptional
+	}
+
+	return api.backend.BroadcastTransaction(base64OrHexTxBytes, mode)
+}
+
+func (api *API) GetUnconfirmedTransactions(limitOptional *int) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_getUnconfirmedTransactions")
+
+	const defaultLimit = 30
+
+	limit := defaultLimit
+	if limitOptional != nil {
+		limit = *limitOptional
+	}
+
+	return api.backend.GetUnconfirmedTransactions(limit)
+}
+
+func (api *API) SearchTransactions(query string, pageNoOptional *int, orderByOptional *string) (berpctypes.GenericBackendResponse, error) {
+	api.logger.Debug("be_searchTransactions")
+
+	pageNo, err := getPageNumber(pageNoOptional)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderBy string
+	if orderByOptional != nil {
+		orderBy = *orderByOptional
+	}
+
+	return api.backend.SearchTransactions(query, pageNo, orderBy)
+}
@@ -18,3 +18,11 @@ func getPageNumber(pageNoOptional *int) (int, error) {
 
 	return tmmath.MaxInt(1, pageNo), nil
 }
+
+func getEventTypes(eventTypesOptional *[]string) []string {
+	if eventTypesOptional == nil {
+		return nil
+	}
+
+	return *eventTypesOptional
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BlockNumber is the block height, or a block tag, accepted by the block query methods.
+// Accepts JSON number, decimal string, hex string with 0x prefix, "latest" and "earliest".
+type BlockNumber int64
+
+const (
+	EarliestBlockNumber = BlockNumber(-2)
+	LatestBlockNumber   = BlockNumber(-1)
+)
+
+func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
+	input := strings.TrimSpace(string(data))
+	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+		input = input[1 : len(input)-1]
+	}
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	switch input {
+	case "latest":
+		*bn = LatestBlockNumber
+		return nil
+	case "earliest":
+		*bn = EarliestBlockNumber
+		return nil
+	}
+
+	var height uint64
+	var err error
+	if strings.HasPrefix(input, "0x") {
+		// bit size 63 to reject the values overflow int64, which would be mistaken as block tags
+		height, err = strconv.ParseUint(input[2:], 16, 63)
+	} else {
+		height, err = strconv.ParseUint(input, 10, 63)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid block number %s", string(data))
+	}
+
+	*bn = BlockNumber(height)
+	return nil
+}
+
+// IsTag returns true if the block number is a block tag, which must be resolved into a height before use.
+func (bn BlockNumber) IsTag() bool {
+	return bn == LatestBlockNumber || bn == EarliestBlockNumber
+}
+
+func (bn BlockNumber) Int64() int64 {
+	return int64(bn)
+}
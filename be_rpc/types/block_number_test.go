@@ -0,0 +1,80 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestBlockNumber_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    BlockNumber
+		wantErr bool
+	}{
+		{
+			input: `1`,
+			want:  1,
+		},
+		{
+			input: `"100"`,
+			want:  100,
+		},
+		{
+			input: `"0x64"`,
+			want:  100,
+		},
+		{
+			input: `"latest"`,
+			want:  LatestBlockNumber,
+		},
+		{
+			input: `"Earliest"`,
+			want:  EarliestBlockNumber,
+		},
+		{
+			input:   `"pending"`,
+			wantErr: true,
+		},
+		{
+			input:   `-1`,
+			wantErr: true,
+		},
+		{
+			input:   `"0xz"`,
+			wantErr: true,
+		},
+		{
+			input:   `"0x"`,
+			wantErr: true,
+		},
+		{
+			input: `"0x7fffffffffffffff"`,
+			want:  BlockNumber(math.MaxInt64),
+		},
+		{
+			input:   `"0xffffffffffffffff"`,
+			wantErr: true,
+		},
+		{
+			input:   `"0x8000000000000000"`,
+			wantErr: true,
+		},
+		{
+			input:   `"9223372036854775808"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var bn BlockNumber
+			err := bn.UnmarshalJSON([]byte(tt.input))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, bn)
+		})
+	}
+}
@@ -17,7 +17,13 @@ type FriendlyResponseContentBuilderI interface {
 	BuildIntoResponse(res GenericBackendResponse)
 }
 
+// FriendlyResponseContentTokenWriterI is optionally implemented by the builders which can write token contract address.
+type FriendlyResponseContentTokenWriterI interface {
+	WriteToken(contractAddress string) FriendlyResponseContentBuilderI
+}
+
 var _ FriendlyResponseContentBuilderI = &friendlyResponseContentBuilder{}
+var _ FriendlyResponseContentTokenWriterI = &friendlyResponseContentBuilder{}
 
 type friendlyResponseContentBuilder struct {
 	// friendlySimple is the simple friendly response content, without ability to inject HTML code.
@@ -75,6 +81,17 @@ func (f *friendlyResponseContentBuilder) WriteCoins(coins sdk.Coins, denomsMetad
 	return f
 }
 
+// WriteToken writes the token contract address, using the "token" pattern so the client can render the token info.
+func (f *friendlyResponseContentBuilder) WriteToken(contractAddress string) FriendlyResponseContentBuilderI {
+	f.friendlySimple.WriteString(contractAddress)
+	if regexAlphaNumericOnly.MatchString(contractAddress) { // only write pattern if content is sanitized
+		f.addMachinePattern("token", contractAddress)
+	} else {
+		f.friendlyMachine.WriteString(contractAddress)
+	}
+	return f
+}
+
 func (f *friendlyResponseContentBuilder) Build() (friendlySimple string, friendlyMachine string) {
 	friendlySimple = f.friendlySimple.String()
 	friendlyMachine = f.friendlyMachine.String()
@@ -44,3 +44,21 @@ func Test_getDisplayNumber(t *testing.T) {
 		})
 	}
 }
+
+func Test_friendlyResponseContentBuilder_WriteToken(t *testing.T) {
+	t.Run("sanitized contract address uses token pattern", func(t *testing.T) {
+		rb := NewFriendlyResponseContentBuilder().WriteText("transfer ")
+		simple, machine := rb.(FriendlyResponseContentTokenWriterI).
+			WriteToken("0x1000000000000000000000000000000000000001").
+			Build()
+		require.Equal(t, "transfer 0x1000000000000000000000000000000000000001", simple)
+		require.Equal(t, "transfer {[{ .[token].[0x1000000000000000000000000000000000000001]. }]}", machine)
+	})
+
+	t.Run("not sanitized contract address is written as is", func(t *testing.T) {
+		rb := NewFriendlyResponseContentBuilder()
+		simple, machine := rb.(FriendlyResponseContentTokenWriterI).WriteToken("<script>").Build()
+		require.Equal(t, "<script>", simple)
+		require.Equal(t, "<script>", machine)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	tmtypes "github.com/tendermint/tendermint/types"
+	"sort"
 	"strings"
 )
 
@@ -18,6 +19,17 @@ const (
 	NftInvolvers     InvolversType = "nft"
 )
 
+// InvolverRole describes why an address is involved in a message.
+type InvolverRole string
+
+const (
+	InvolverRoleSender    InvolverRole = "sender"
+	InvolverRoleRecipient InvolverRole = "recipient"
+	InvolverRoleValidator InvolverRole = "validator"
+	InvolverRoleGranter   InvolverRole = "granter"
+	InvolverRoleGrantee   InvolverRole = "grantee"
+)
+
 type MessageInvolversResult map[InvolversType][]string
 
 func (m MessageInvolversResult) Merge(other MessageInvolversResult) MessageInvolversResult {
@@ -61,6 +73,74 @@ func (m MessageInvolversResult) Finalize() MessageInvolversResult {
 	return r
 }
 
+// MessageInvolverRoles holds the roles of the addresses involved in a message, keyed by address.
+type MessageInvolverRoles map[string][]InvolverRole
+
+func (r MessageInvolverRoles) Merge(other MessageInvolverRoles) MessageInvolverRoles {
+	for k, v := range other {
+		r[k] = append(r[k], v...)
+	}
+	return r
+}
+
+func (r MessageInvolverRoles) Add(role InvolverRole, addresses ...string) {
+	for _, address := range addresses {
+		address := normalizeAddress(address)
+		if len(address) == 0 {
+			continue
+		}
+
+		spl := strings.Split(address, "/") // remove the suffix
+		address = spl[0]
+
+		r[address] = append(r[address], role)
+	}
+}
+
+// Finalize removes duplicated roles and sorts the roles of each address.
+func (r MessageInvolverRoles) Finalize() MessageInvolverRoles {
+	res := make(MessageInvolverRoles)
+	for addr, roles := range r {
+		unique := make(map[InvolverRole]bool)
+		for _, role := range roles {
+			unique[role] = true
+		}
+		res[addr] = make([]InvolverRole, 0, len(unique))
+		for role := range unique {
+			res[addr] = append(res[addr], role)
+		}
+		sort.Slice(res[addr], func(i, j int) bool {
+			return res[addr][i] < res[addr][j]
+		})
+	}
+	return res
+}
+
+// MessageInvolversWithRoles holds the involvers of a message, with the roles of the addresses when known.
+type MessageInvolversWithRoles struct {
+	Involvers MessageInvolversResult
+	Roles     MessageInvolverRoles
+}
+
+func NewMessageInvolversWithRoles() MessageInvolversWithRoles {
+	return MessageInvolversWithRoles{
+		Involvers: make(MessageInvolversResult),
+		Roles:     make(MessageInvolverRoles),
+	}
+}
+
+// AddWithRole adds the addresses as message involvers, with the role describing why they are involved.
+func (m MessageInvolversWithRoles) AddWithRole(role InvolverRole, addresses ...string) {
+	m.Involvers.Add(MessageInvolvers, addresses...)
+	m.Roles.Add(role, addresses...)
+}
+
+func (m MessageInvolversWithRoles) Merge(other MessageInvolversWithRoles) MessageInvolversWithRoles {
+	m.Involvers = m.Involvers.Merge(other.Involvers)
+	m.Roles = m.Roles.Merge(other.Roles)
+	return m
+}
+
 func normalizeAddress(address string) string {
 	return strings.ToLower(strings.TrimSpace(address))
 }
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+)
+
+func TestMessageInvolversWithRoles_AddWithRole(t *testing.T) {
+	res := NewMessageInvolversWithRoles()
+	res.AddWithRole(InvolverRoleSender, "addr1")
+	res.AddWithRole(InvolverRoleRecipient, " ADDR2 ", "")
+	res.AddWithRole(InvolverRoleValidator, "val1")
+	res.Involvers.Add(Erc20Involvers, "addr3")
+
+	finalized := res.Involvers.Finalize()
+	sort.Strings(finalized[MessageInvolvers])
+	require.Equal(t, MessageInvolversResult{
+		MessageInvolvers: {"addr1", "addr2", "val1"},
+		Erc20Involvers:   {"addr3"},
+	}, finalized)
+
+	require.Equal(t, MessageInvolverRoles{
+		"addr1": {InvolverRoleSender},
+		"addr2": {InvolverRoleRecipient},
+		"val1":  {InvolverRoleValidator},
+	}, res.Roles.Finalize())
+}
+
+func TestMessageInvolverRoles_Finalize(t *testing.T) {
+	t.Run("merge roles of the same address", func(t *testing.T) {
+		res := NewMessageInvolversWithRoles()
+		res.AddWithRole(InvolverRoleSender, "addr1")
+		res.AddWithRole(InvolverRoleGrantee, "addr1")
+
+		other := NewMessageInvolversWithRoles()
+		other.AddWithRole(InvolverRoleSender, "ADDR1")
+		other.AddWithRole(InvolverRoleGranter, "addr2")
+
+		res = res.Merge(other)
+
+		require.Equal(t, MessageInvolverRoles{
+			"addr1": {InvolverRoleGrantee, InvolverRoleSender},
+			"addr2": {InvolverRoleGranter},
+		}, res.Roles.Finalize())
+
+		require.Len(t, res.Involvers.Finalize()[MessageInvolvers], 2)
+	})
+
+	t.Run("involvers without role are excluded", func(t *testing.T) {
+		res := NewMessageInvolversWithRoles()
+		res.Involvers.Add(MessageInvolvers, "addr1")
+
+		require.Empty(t, res.Roles.Finalize())
+		require.Equal(t, []string{"addr1"}, res.Involvers.Finalize()[MessageInvolvers])
+	})
+}
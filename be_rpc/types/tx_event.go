@@ -36,6 +36,24 @@ func ConvertTxEvent(events []abci.Event) TxEvents {
 	return res
 }
 
+// FilterByType returns the events of the given types. Returns all events if no type provided.
+func (m TxEvents) FilterByType(eventTypes ...string) TxEvents {
+	if len(eventTypes) == 0 {
+		return m
+	}
+
+	res := make(TxEvents, 0)
+	for _, event := range m {
+		for _, eventType := range eventTypes {
+			if event.Type == eventType {
+				res = append(res, event)
+				break
+			}
+		}
+	}
+	return res
+}
+
 func (m TxEvents) RemoveUnnecessaryEvmTxEvents() TxEvents {
 	remove := func() TxEvents {
 		txEventsTruncatedEvm := make([]TxEvent, 0)
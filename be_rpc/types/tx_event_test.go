@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTxEvents_FilterByType(t *testing.T) {
+	events := TxEvents{
+		{Type: "mint"},
+		{Type: "transfer"},
+		{Type: "rewards"},
+		{Type: "transfer"},
+	}
+
+	t.Run("no filter returns all", func(t *testing.T) {
+		require.Equal(t, events, events.FilterByType())
+	})
+
+	t.Run("filter by single type", func(t *testing.T) {
+		require.Equal(t, TxEvents{{Type: "transfer"}, {Type: "transfer"}}, events.FilterByType("transfer"))
+	})
+
+	t.Run("filter by multiple types", func(t *testing.T) {
+		require.Equal(t, TxEvents{{Type: "mint"}, {Type: "rewards"}}, events.FilterByType("mint", "rewards"))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		require.Empty(t, events.FilterByType("slash"))
+	})
+}
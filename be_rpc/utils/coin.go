@@ -16,6 +16,14 @@ func CoinsToMap(coins ...sdk.Coin) map[string]string {
 	return m
 }
 
+func DecCoinsToMap(coins ...sdk.DecCoin) map[string]string {
+	m := make(map[string]string)
+	for _, coin := range coins {
+		m[coin.Denom] = coin.Amount.String()
+	}
+	return m
+}
+
 func GetIncomingIBCCoin(srcPort, srcChannel string, dstPort, dstChannel string, denom, amt string) (sdk.Coin, error) {
 	amount, ok := math.NewIntFromString(amt)
 	if !ok {
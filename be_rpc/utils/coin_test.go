@@ -90,3 +90,15 @@ func TestGetIncomingIBCCoin(t *testing.T) {
 		})
 	}
 }
+
+func TestDecCoinsToMap(t *testing.T) {
+	require.Empty(t, DecCoinsToMap())
+
+	require.Equal(t, map[string]string{
+		"uatom": "1.500000000000000000",
+		"stake": "2.000000000000000000",
+	}, DecCoinsToMap(
+		sdk.NewDecCoinFromDec("uatom", sdk.NewDecWithPrec(15, 1)),
+		sdk.NewDecCoin("stake", math.NewInt(2)),
+	))
+}
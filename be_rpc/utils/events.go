@@ -1,6 +1,7 @@
 package utils
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -25,3 +26,28 @@ func IsEventTypeWithAllAttributes(event abci.Event, eventType string, attributeK
 
 	return true, keyToValue
 }
+
+// SplitTxEventsByMessage splits the events of a transaction into the events emitted by each message.
+// The events of each message are started by the `message` event with `action` attribute, prepended by the base app.
+// The events emitted before the first message, like the ante handler events, are not included.
+// Returns false if the number of the messages found in the events does not match the given count.
+func SplitTxEventsByMessage(events []abci.Event, msgCount int) ([][]abci.Event, bool) {
+	var msgsEvents [][]abci.Event
+	for _, event := range events {
+		if ok, _ := IsEventTypeWithAllAttributes(event, sdk.EventTypeMessage, sdk.AttributeKeyAction); ok {
+			msgsEvents = append(msgsEvents, []abci.Event{event})
+			continue
+		}
+
+		if len(msgsEvents) < 1 {
+			continue
+		}
+		msgsEvents[len(msgsEvents)-1] = append(msgsEvents[len(msgsEvents)-1], event)
+	}
+
+	if len(msgsEvents) != msgCount {
+		return nil, false
+	}
+
+	return msgsEvents, true
+}
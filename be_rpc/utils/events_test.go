@@ -58,3 +58,39 @@ func TestIsEventTypeWithAllAttributes(t *testing.T) {
 	}, "test", "key3", "key4")
 	require.False(t, ok)
 }
+
+func TestSplitTxEventsByMessage(t *testing.T) {
+	newEvent := func(eventType string, kv ...string) abci.Event {
+		event := abci.Event{Type: eventType}
+		for i := 0; i < len(kv); i += 2 {
+			event.Attributes = append(event.Attributes, abci.EventAttribute{Key: []byte(kv[i]), Value: []byte(kv[i+1])})
+		}
+		return event
+	}
+
+	anteEvent := newEvent("tx", "fee", "1stake")
+	msg1Action := newEvent("message", "action", "/msg1")
+	msg1Event := newEvent("transfer", "recipient", "addr1")
+	msg1ModuleEvent := newEvent("message", "module", "bank", "sender", "addr0")
+	msg2Action := newEvent("message", "action", "/msg2")
+	msg2Event := newEvent("transfer", "recipient", "addr2")
+
+	events := []abci.Event{anteEvent, msg1Action, msg1Event, msg1ModuleEvent, msg2Action, msg2Event}
+
+	msgsEvents, ok := SplitTxEventsByMessage(events, 2)
+	require.True(t, ok)
+	require.Equal(t, [][]abci.Event{
+		{msg1Action, msg1Event, msg1ModuleEvent},
+		{msg2Action, msg2Event},
+	}, msgsEvents)
+
+	_, ok = SplitTxEventsByMessage(events, 1)
+	require.False(t, ok, "number of messages mismatch")
+
+	_, ok = SplitTxEventsByMessage([]abci.Event{anteEvent}, 1)
+	require.False(t, ok, "no message event")
+
+	msgsEvents, ok = SplitTxEventsByMessage(nil, 0)
+	require.True(t, ok)
+	require.Empty(t, msgsEvents)
+}
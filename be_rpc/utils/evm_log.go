@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"encoding/json"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"math/big"
 )
 
@@ -60,3 +63,209 @@ func IsEvmEventMatch(
 func AccAddressFromTopic(topic common.Hash) sdk.AccAddress {
 	return common.BytesToAddress(topic.Bytes()[12:]).Bytes()
 }
+
+// EvmLog is the EVM log emitted within the `tx_log` event.
+type EvmLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    []byte         `json:"data"`
+}
+
+// ParseEvmLogsFromTxEvents parses the EVM logs from the `tx_log` events, malformed logs are ignored.
+func ParseEvmLogsFromTxEvents(events []abci.Event) []EvmLog {
+	var logs []EvmLog
+	for _, event := range events {
+		if event.Type != berpctypes.EventTypeTxLog {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != berpctypes.AttributeKeyTxLog {
+				continue
+			}
+			var log EvmLog
+			if err := json.Unmarshal(attr.Value, &log); err != nil {
+				continue
+			}
+			if len(log.Topics) < 1 {
+				continue
+			}
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// ExtractInvolversFromEvmLogs classifies the token related EVM logs, the token contract and holder addresses
+// are put into the ERC-20 or NFT involvers. Supported events:
+// - ERC-20 & ERC-721 Transfer
+// - ERC-1155 TransferSingle & TransferBatch
+// - WETH Deposit & Withdrawal
+func ExtractInvolversFromEvmLogs(logs []EvmLog) berpctypes.MessageInvolversResult {
+	res := make(berpctypes.MessageInvolversResult)
+
+	addHolders := func(t berpctypes.InvolversType, contract common.Address, holderTopics ...common.Hash) {
+		res.Add(t, sdk.AccAddress(contract.Bytes()).String())
+		for _, topic := range holderTopics {
+			if topic == (common.Hash{}) {
+				// mint or burn
+				continue
+			}
+			res.Add(t, AccAddressFromTopic(topic).String())
+		}
+	}
+
+	for _, log := range logs {
+		topics := log.Topics
+		switch {
+		case IsEvmEventMatch(topics, log.Data, 3, berpctypes.EvmEvent_Erc20_Erc721_Transfer, true, true, false, true):
+			addHolders(berpctypes.Erc20Involvers, log.Address, topics[1], topics[2])
+		case IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc20_Erc721_Transfer, true, true, false, false):
+			addHolders(berpctypes.NftInvolvers, log.Address, topics[1], topics[2])
+		case IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc1155_TransferSingle, true, true, true, true),
+			IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc1155_TransferBatch, true, true, true, true):
+			addHolders(berpctypes.NftInvolvers, log.Address, topics[2], topics[3])
+		case IsEvmEventMatch(topics, log.Data, 2, berpctypes.EvmEvent_WDeposit, true, false, false, true),
+			IsEvmEventMatch(topics, log.Data, 2, berpctypes.EvmEvent_WWithdraw, true, false, false, true):
+			addHolders(berpctypes.Erc20Involvers, log.Address, topics[1])
+		}
+	}
+
+	return res
+}
+
+const (
+	EvmTokenStandardErc20   = "erc20"
+	EvmTokenStandardErc721  = "erc721"
+	EvmTokenStandardErc1155 = "erc1155"
+)
+
+// EvmTokenTransfer is a token transfer decoded from the EVM logs.
+// Amount is provided for ERC-20 & ERC-1155, TokenId is provided for ERC-721 & ERC-1155.
+// Mint and burn are transfers from and to the zero address.
+type EvmTokenTransfer struct {
+	Standard string
+	Contract common.Address
+	From     common.Address
+	To       common.Address
+	Amount   *big.Int
+	TokenId  *big.Int
+}
+
+// DecodeEvmTokenTransfers decodes the token transfers from the EVM logs, supported events:
+// - ERC-20 & ERC-721 Transfer
+// - ERC-1155 TransferSingle & TransferBatch, each token id of the batch is a transfer
+// - WETH Deposit & Withdrawal, as ERC-20 mint & burn
+func DecodeEvmTokenTransfers(logs []EvmLog) []EvmTokenTransfer {
+	var transfers []EvmTokenTransfer
+
+	topicToAddress := func(topic common.Hash) common.Address {
+		return common.BytesToAddress(topic.Bytes()[12:])
+	}
+
+	for _, log := range logs {
+		topics := log.Topics
+		switch {
+		case IsEvmEventMatch(topics, log.Data, 3, berpctypes.EvmEvent_Erc20_Erc721_Transfer, true, true, false, true):
+			if len(log.Data) != 32 {
+				continue
+			}
+			transfers = append(transfers, EvmTokenTransfer{
+				Standard: EvmTokenStandardErc20,
+				Contract: log.Address,
+				From:     topicToAddress(topics[1]),
+				To:       topicToAddress(topics[2]),
+				Amount:   new(big.Int).SetBytes(log.Data),
+			})
+		case IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc20_Erc721_Transfer, true, true, false, false):
+			transfers = append(transfers, EvmTokenTransfer{
+				Standard: EvmTokenStandardErc721,
+				Contract: log.Address,
+				From:     topicToAddress(topics[1]),
+				To:       topicToAddress(topics[2]),
+				TokenId:  topics[3].Big(),
+			})
+		case IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc1155_TransferSingle, true, true, true, true):
+			if len(log.Data) != 64 {
+				continue
+			}
+			transfers = append(transfers, EvmTokenTransfer{
+				Standard: EvmTokenStandardErc1155,
+				Contract: log.Address,
+				From:     topicToAddress(topics[2]),
+				To:       topicToAddress(topics[3]),
+				TokenId:  new(big.Int).SetBytes(log.Data[:32]),
+				Amount:   new(big.Int).SetBytes(log.Data[32:]),
+			})
+		case IsEvmEventMatch(topics, log.Data, 4, berpctypes.EvmEvent_Erc1155_TransferBatch, true, true, true, true):
+			ids, okIds := decodeAbiUint256Array(log.Data, 0)
+			values, okValues := decodeAbiUint256Array(log.Data, 1)
+			if !okIds || !okValues || len(ids) != len(values) {
+				continue
+			}
+			for i := range ids {
+				transfers = append(transfers, EvmTokenTransfer{
+					Standard: EvmTokenStandardErc1155,
+					Contract: log.Address,
+					From:     topicToAddress(topics[2]),
+					To:       topicToAddress(topics[3]),
+					TokenId:  ids[i],
+					Amount:   values[i],
+				})
+			}
+		case IsEvmEventMatch(topics, log.Data, 2, berpctypes.EvmEvent_WDeposit, true, false, false, true):
+			if len(log.Data) != 32 {
+				continue
+			}
+			transfers = append(transfers, EvmTokenTransfer{
+				Standard: EvmTokenStandardErc20,
+				Contract: log.Address,
+				To:       topicToAddress(topics[1]),
+				Amount:   new(big.Int).SetBytes(log.Data),
+			})
+		case IsEvmEventMatch(topics, log.Data, 2, berpctypes.EvmEvent_WWithdraw, true, false, false, true):
+			if len(log.Data) != 32 {
+				continue
+			}
+			transfers = append(transfers, EvmTokenTransfer{
+				Standard: EvmTokenStandardErc20,
+				Contract: log.Address,
+				From:     topicToAddress(topics[1]),
+				Amount:   new(big.Int).SetBytes(log.Data),
+			})
+		}
+	}
+
+	return transfers
+}
+
+// decodeAbiUint256Array decodes the ABI encoded dynamic uint256[] of the given argument index.
+func decodeAbiUint256Array(data []byte, argIndex int) ([]*big.Int, bool) {
+	const wordSize = 32
+
+	readWord := func(offset uint64) (*big.Int, bool) {
+		if offset > uint64(len(data)) || uint64(len(data))-offset < wordSize {
+			return nil, false
+		}
+		return new(big.Int).SetBytes(data[offset : offset+wordSize]), true
+	}
+
+	offset, ok := readWord(uint64(argIndex * wordSize))
+	if !ok || !offset.IsUint64() {
+		return nil, false
+	}
+
+	length, ok := readWord(offset.Uint64())
+	if !ok || !length.IsUint64() || length.Uint64() > uint64(len(data))/wordSize {
+		return nil, false
+	}
+
+	res := make([]*big.Int, length.Uint64())
+	for i := range res {
+		res[i], ok = readWord(offset.Uint64() + wordSize*uint64(i+1))
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return res, true
+}
@@ -1,9 +1,14 @@
 package utils
 
 import (
+	"encoding/json"
+	berpctypes "github.com/bcdevtools/block-explorer-rpc-cosmos/be_rpc/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"math/big"
+	"sort"
 	"testing"
 )
 
@@ -260,3 +265,269 @@ func TestAccAddressFromTopic(t *testing.T) {
 		AccAddressFromTopic(addrTopic),
 	)
 }
+
+func TestParseEvmLogsFromTxEvents(t *testing.T) {
+	log := EvmLog{
+		Address: common.HexToAddress("0x1000000000000000000000000000000000000001"),
+		Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer},
+		Data:    []byte{1, 2, 3},
+	}
+	bz, err := json.Marshal(log)
+	require.NoError(t, err)
+
+	events := []abci.Event{
+		{
+			Type: berpctypes.EventTypeTxLog,
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(berpctypes.AttributeKeyTxLog), Value: bz},
+				{Key: []byte(berpctypes.AttributeKeyTxLog), Value: []byte("malformed")},
+				{Key: []byte(berpctypes.AttributeKeyTxLog), Value: []byte(`{"address":"0x1000000000000000000000000000000000000001","topics":[]}`)},
+			},
+		},
+		{
+			Type: "transfer",
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(berpctypes.AttributeKeyTxLog), Value: bz},
+			},
+		},
+	}
+
+	require.Equal(t, []EvmLog{log}, ParseEvmLogsFromTxEvents(events))
+}
+
+func TestExtractInvolversFromEvmLogs(t *testing.T) {
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	addr1 := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	addr2 := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	operator := common.HexToAddress("0x4000000000000000000000000000000000000004")
+
+	bech32 := func(addr common.Address) string {
+		return sdk.AccAddress(addr.Bytes()).String()
+	}
+	topic := func(addr common.Address) common.Hash {
+		return common.BytesToHash(addr.Bytes())
+	}
+	sorted := func(addresses ...string) []string {
+		sort.Strings(addresses)
+		return addresses
+	}
+	finalize := func(res berpctypes.MessageInvolversResult) berpctypes.MessageInvolversResult {
+		res = res.Finalize()
+		for k := range res {
+			res[k] = sorted(res[k]...)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name string
+		log  EvmLog
+		want berpctypes.MessageInvolversResult
+	}{
+		{
+			name: "ERC-20 Transfer",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer, topic(addr1), topic(addr2)},
+				Data:    common.BigToHash(common.Big1).Bytes(),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.Erc20Involvers: sorted(bech32(contract), bech32(addr1), bech32(addr2)),
+			},
+		},
+		{
+			name: "ERC-20 mint, zero address is skipped",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer, {}, topic(addr2)},
+				Data:    common.BigToHash(common.Big1).Bytes(),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.Erc20Involvers: sorted(bech32(contract), bech32(addr2)),
+			},
+		},
+		{
+			name: "ERC-721 Transfer",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer, topic(addr1), topic(addr2), common.BigToHash(common.Big2)},
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.NftInvolvers: sorted(bech32(contract), bech32(addr1), bech32(addr2)),
+			},
+		},
+		{
+			name: "ERC-1155 TransferSingle",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc1155_TransferSingle, topic(operator), topic(addr1), topic(addr2)},
+				Data:    make([]byte, 64),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.NftInvolvers: sorted(bech32(contract), bech32(addr1), bech32(addr2)),
+			},
+		},
+		{
+			name: "ERC-1155 TransferBatch",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc1155_TransferBatch, topic(operator), topic(addr1), topic(addr2)},
+				Data:    make([]byte, 128),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.NftInvolvers: sorted(bech32(contract), bech32(addr1), bech32(addr2)),
+			},
+		},
+		{
+			name: "WETH Deposit",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_WDeposit, topic(addr1)},
+				Data:    common.BigToHash(common.Big1).Bytes(),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.Erc20Involvers: sorted(bech32(contract), bech32(addr1)),
+			},
+		},
+		{
+			name: "WETH Withdrawal",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_WWithdraw, topic(addr1)},
+				Data:    common.BigToHash(common.Big1).Bytes(),
+			},
+			want: berpctypes.MessageInvolversResult{
+				berpctypes.Erc20Involvers: sorted(bech32(contract), bech32(addr1)),
+			},
+		},
+		{
+			name: "Approval is ignored",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Approval, topic(addr1), topic(addr2)},
+				Data:    common.BigToHash(common.Big1).Bytes(),
+			},
+			want: berpctypes.MessageInvolversResult{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, finalize(ExtractInvolversFromEvmLogs([]EvmLog{tt.log})))
+		})
+	}
+}
+
+func TestDecodeEvmTokenTransfers(t *testing.T) {
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	addr1 := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	addr2 := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	operator := common.HexToAddress("0x4000000000000000000000000000000000000004")
+
+	topic := func(addr common.Address) common.Hash {
+		return common.BytesToHash(addr.Bytes())
+	}
+	words := func(values ...int64) []byte {
+		var bz []byte
+		for _, value := range values {
+			bz = append(bz, common.BigToHash(big.NewInt(value)).Bytes()...)
+		}
+		return bz
+	}
+
+	tests := []struct {
+		name string
+		log  EvmLog
+		want []EvmTokenTransfer
+	}{
+		{
+			name: "ERC-20 Transfer",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer, topic(addr1), topic(addr2)},
+				Data:    words(100),
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc20, Contract: contract, From: addr1, To: addr2, Amount: big.NewInt(100)},
+			},
+		},
+		{
+			name: "ERC-721 Transfer",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Transfer, topic(addr1), topic(addr2), common.BigToHash(big.NewInt(7))},
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc721, Contract: contract, From: addr1, To: addr2, TokenId: big.NewInt(7)},
+			},
+		},
+		{
+			name: "ERC-1155 TransferSingle",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc1155_TransferSingle, topic(operator), topic(addr1), topic(addr2)},
+				Data:    words(7, 3),
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc1155, Contract: contract, From: addr1, To: addr2, TokenId: big.NewInt(7), Amount: big.NewInt(3)},
+			},
+		},
+		{
+			name: "ERC-1155 TransferBatch",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc1155_TransferBatch, topic(operator), topic(addr1), topic(addr2)},
+				// offset of ids, offset of values, ids = [7, 8], values = [3, 4]
+				Data: words(64, 160, 2, 7, 8, 2, 3, 4),
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc1155, Contract: contract, From: addr1, To: addr2, TokenId: big.NewInt(7), Amount: big.NewInt(3)},
+				{Standard: EvmTokenStandardErc1155, Contract: contract, From: addr1, To: addr2, TokenId: big.NewInt(8), Amount: big.NewInt(4)},
+			},
+		},
+		{
+			name: "ERC-1155 TransferBatch with malformed data",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc1155_TransferBatch, topic(operator), topic(addr1), topic(addr2)},
+				Data:    words(64, 1000, 2, 7, 8),
+			},
+			want: nil,
+		},
+		{
+			name: "WETH Deposit",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_WDeposit, topic(addr1)},
+				Data:    words(5),
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc20, Contract: contract, To: addr1, Amount: big.NewInt(5)},
+			},
+		},
+		{
+			name: "WETH Withdrawal",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_WWithdraw, topic(addr1)},
+				Data:    words(5),
+			},
+			want: []EvmTokenTransfer{
+				{Standard: EvmTokenStandardErc20, Contract: contract, From: addr1, Amount: big.NewInt(5)},
+			},
+		},
+		{
+			name: "Approval is ignored",
+			log: EvmLog{
+				Address: contract,
+				Topics:  []common.Hash{berpctypes.EvmEvent_Erc20_Erc721_Approval, topic(addr1), topic(addr2)},
+				Data:    words(5),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, DecodeEvmTokenTransfers([]EvmLog{tt.log}))
+		})
+	}
+}
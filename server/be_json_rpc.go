@@ -68,12 +68,14 @@ func StartBeJsonRPC(
 
 	var handlerFunc func(http.ResponseWriter, *http.Request)
 	var handlerWithCors *cors.Cors
+	var wsAllowedOrigins []string
 	if config.AllowCORS {
 		handlerFunc = func(writer http.ResponseWriter, request *http.Request) {
 			addCorsHeaders(request.Method, writer)
 			rpcServer.ServeHTTP(writer, request)
 		}
 		handlerWithCors = cors.AllowAll()
+		wsAllowedOrigins = []string{"*"}
 	} else {
 		handlerFunc = rpcServer.ServeHTTP
 		handlerWithCors = cors.Default()
@@ -81,6 +83,8 @@ func StartBeJsonRPC(
 
 	r := mux.NewRouter()
 	r.HandleFunc("/", handlerFunc).Methods("POST")
+	r.Handle("/", rpcServer.WebsocketHandler(wsAllowedOrigins)).Methods("GET") // websocket, for subscriptions
+	r.Handle("/sse/blocks", newNewBlocksSseStreamer(rpcServer, config.MaxSSEStreams, ctx.Logger.With("module", "be_sse"))).Methods("GET")
 
 	httpSrv := &http.Server{
 		Addr:              config.Address,
@@ -90,6 +94,7 @@ func StartBeJsonRPC(
 		WriteTimeout:      config.HTTPTimeout,
 		IdleTimeout:       config.HTTPIdleTimeout,
 	}
+	httpSrv.RegisterOnShutdown(be_rpc.Shutdown)
 	httpSrvDone := make(chan struct{}, 1)
 
 	ln, err := listen(httpSrv.Addr, config)
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// sseMaxBlocksPerQuery must not be greater than the page size of be_getTransactionsInBlockRange.
+	sseMaxBlocksPerQuery = 100
+	// sseMaxResumeBlocks is the maximum number of blocks to be re-streamed when client resumes using Last-Event-ID.
+	sseMaxResumeBlocks = 1000
+	// sseHeartbeatInterval is the interval of the heartbeat comment, to keep the connection alive through proxies.
+	sseHeartbeatInterval = 15 * time.Second
+	// sseWriteTimeout is the timeout of each write, to release the stream of dead clients.
+	sseWriteTimeout = 30 * time.Second
+)
+
+// newBlocksSseStreamer streams new blocks, with the transactions in each block, as server-sent events.
+// Each event carries the block height as the event ID so client can resume using Last-Event-ID header.
+type newBlocksSseStreamer struct {
+	client        *ethrpc.Client
+	logger        tmlog.Logger
+	maxStreams    int64
+	activeStreams int64
+}
+
+func newNewBlocksSseStreamer(rpcServer *ethrpc.Server, maxStreams int, logger tmlog.Logger) *newBlocksSseStreamer {
+	return &newBlocksSseStreamer{
+		client:     ethrpc.DialInProc(rpcServer),
+		logger:     logger,
+		maxStreams: int64(maxStreams),
+	}
+}
+
+type sseNewBlockHead struct {
+	Height int64  `json:"height"`
+	Error  string `json:"error,omitempty"` // provided when the feed stopped
+}
+
+type sseBlockRangeResult struct {
+	ChainId       string                    `json:"chainId"`
+	Blocks        map[int64]sseBlockSummary `json:"blocks"`
+	MissingBlocks []int64                   `json:"missingBlocks"`
+	ErrorBlocks   []int64                   `json:"errorBlocks"`
+}
+
+type sseBlockSummary struct {
+	TimeEpochUTC int64             `json:"timeEpochUTC"`
+	Txs          []json.RawMessage `json:"txs"`
+}
+
+func (s *newBlocksSseStreamer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	activeStreams := atomic.AddInt64(&s.activeStreams, 1)
+	defer atomic.AddInt64(&s.activeStreams, -1)
+	if s.maxStreams > 0 && activeStreams > s.maxStreams {
+		http.Error(writer, "too many streams", http.StatusServiceUnavailable)
+		return
+	}
+
+	var lastHeight int64
+	lastEventId := strings.TrimSpace(request.Header.Get("Last-Event-ID"))
+	if lastEventId == "" {
+		lastEventId = strings.TrimSpace(request.URL.Query().Get("lastEventId"))
+	}
+	if lastEventId != "" {
+		var err error
+		lastHeight, err = strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil || lastHeight < 0 {
+			http.Error(writer, "bad Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+	resumed := lastEventId != ""
+
+	// cancelled when the client disconnected
+	ctx, cancel := context.WithCancel(request.Context())
+	defer cancel()
+
+	heads := make(chan sseNewBlockHead, 16)
+	sub, err := s.client.Subscribe(ctx, "be", heads, "newBlockHeights")
+	if err != nil {
+		s.logger.Error("failed to subscribe new block heights for SSE stream", "error", err)
+		http.Error(writer, "failed to subscribe new blocks", http.StatusInternalServerError)
+		return
+	}
+	defer sub.Unsubscribe()
+
+	stream := &sseStream{
+		writer:     writer,
+		controller: http.NewResponseController(writer),
+	}
+
+	// the read/write timeout of the http server is not suitable for long-lived stream,
+	// the read deadline is removed and the write deadline is extended on each write instead.
+	if err := stream.controller.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.logger.Error("failed to remove read deadline for SSE stream", "error", err)
+		return
+	}
+
+	if err := stream.writeHeaders(); err != nil {
+		return
+	}
+
+	if resumed {
+		var chainInfo struct {
+			LatestBlock int64 `json:"latestBlock"`
+		}
+		if err := s.client.CallContext(ctx, &chainInfo, "be_getChainInfo"); err != nil {
+			s.logger.Error("failed to get chain info for SSE stream", "error", err)
+			return
+		}
+
+		fromHeight := sseStreamFromHeight(lastHeight, resumed, chainInfo.LatestBlock)
+		lastHeight, err = s.streamBlocks(ctx, stream, fromHeight, chainInfo.LatestBlock, lastHeight)
+		if err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case head := <-heads:
+			if head.Error != "" {
+				s.logger.Error("new block heights feed stopped, closing SSE stream", "error", head.Error)
+				return
+			}
+			if resumed && head.Height <= lastHeight {
+				continue // already streamed
+			}
+
+			fromHeight := sseStreamFromHeight(lastHeight, resumed, head.Height)
+			lastHeight, err = s.streamBlocks(ctx, stream, fromHeight, head.Height, lastHeight)
+			if err != nil {
+				return
+			}
+			resumed = true
+		case <-heartbeat.C:
+			if err := stream.writeComment("heartbeat"); err != nil {
+				return
+			}
+		case <-sub.Err():
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sseStreamFromHeight returns the height to stream from, up to the given height.
+// When resuming, it is the height next to the last streamed height, limited to the last sseMaxResumeBlocks blocks,
+// otherwise only the given height is streamed.
+func sseStreamFromHeight(lastHeight int64, resumed bool, toHeight int64) int64 {
+	if !resumed {
+		return toHeight
+	}
+
+	fromHeight := lastHeight + 1
+	if toHeight-fromHeight+1 > sseMaxResumeBlocks {
+		fromHeight = toHeight - sseMaxResumeBlocks + 1
+	}
+	return fromHeight
+}
+
+// streamBlocks writes the blocks within the range into the stream, returns the last streamed height.
+func (s *newBlocksSseStreamer) streamBlocks(ctx context.Context, stream *sseStream, fromHeight, toHeight, lastHeight int64) (int64, error) {
+	for from := fromHeight; from <= toHeight; from += sseMaxBlocksPerQuery {
+		to := from + sseMaxBlocksPerQuery - 1
+		if to > toHeight {
+			to = toHeight
+		}
+
+		var blockRange sseBlockRangeResult
+		if err := s.client.CallContext(ctx, &blockRange, "be_getTransactionsInBlockRange", from, to); err != nil {
+			s.logger.Error("failed to get transactions in block range for SSE stream", "from", from, "to", to, "error", err)
+			return lastHeight, err
+		}
+
+		heights := make([]int64, 0, len(blockRange.Blocks)+len(blockRange.MissingBlocks)+len(blockRange.ErrorBlocks))
+		for height := range blockRange.Blocks {
+			heights = append(heights, height)
+		}
+		heights = append(heights, blockRange.MissingBlocks...)
+		heights = append(heights, blockRange.ErrorBlocks...)
+		sort.Slice(heights, func(i, j int) bool {
+			return heights[i] < heights[j]
+		})
+
+		for _, height := range heights {
+			block, found := blockRange.Blocks[height]
+			if !found {
+				// missing or failed to fetch, notify client so it can fetch the block later
+				if err := stream.writeEvent(height, "missingBlock", map[string]any{
+					"chainId": blockRange.ChainId,
+					"height":  height,
+				}); err != nil {
+					return lastHeight, err
+				}
+				continue
+			}
+
+			txs := block.Txs
+			if txs == nil {
+				txs = make([]json.RawMessage, 0)
+			}
+
+			if err := stream.writeEvent(height, "newBlock", map[string]any{
+				"chainId":      blockRange.ChainId,
+				"height":       height,
+				"timeEpochUTC": block.TimeEpochUTC,
+				"txs":          txs,
+			}); err != nil {
+				return lastHeight, err
+			}
+		}
+
+		lastHeight = to
+	}
+
+	return lastHeight, nil
+}
+
+// sseStream writes server-sent events into the response.
+type sseStream struct {
+	writer     http.ResponseWriter
+	controller *http.ResponseController
+}
+
+func (s *sseStream) writeHeaders() error {
+	header := s.writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+
+	if err := s.extendWriteDeadline(); err != nil {
+		return err
+	}
+
+	s.writer.WriteHeader(http.StatusOK)
+	return s.controller.Flush()
+}
+
+func (s *sseStream) writeEvent(id int64, event string, data any) error {
+	bz, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", id, event, string(bz)))
+}
+
+func (s *sseStream) writeComment(comment string) error {
+	return s.write(fmt.Sprintf(": %s\n\n", comment))
+}
+
+func (s *sseStream) write(content string) error {
+	if err := s.extendWriteDeadline(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(s.writer, content); err != nil {
+		return err
+	}
+
+	return s.controller.Flush()
+}
+
+// extendWriteDeadline extends the write deadline for the next write, to release the stream of dead clients.
+func (s *sseStream) extendWriteDeadline() error {
+	err := s.controller.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}